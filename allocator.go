@@ -0,0 +1,12 @@
+package ringbuffer
+
+// WithAllocator obtains the backing array from alloc instead of the
+// heap, for example from a per-session arena that is released in bulk.
+// alloc is called once with the capacity and must return at least that
+// many bytes. The caller keeps the memory alive for the buffer's
+// lifetime; Clone copies into ordinary heap memory.
+func WithAllocator(alloc func(n int) []byte) Option {
+	return func(rb *RingBuffer) {
+		rb.buf = alloc(rb.capacity)
+	}
+}
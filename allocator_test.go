@@ -0,0 +1,32 @@
+package ringbuffer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WithAllocator(t *testing.T) {
+
+	pool := make([]byte, 64)
+	var calls []int
+	alloc := func(n int) []byte {
+		calls = append(calls, n)
+		p := pool[:n:n]
+		pool = pool[n:]
+		return p
+	}
+
+	a := NewRingBuffer(16, WithAllocator(alloc))
+	b := NewRingBuffer(8, WithAllocator(alloc))
+	assert.Equal(t, []int{16, 8}, calls)
+	assert.Equal(t, 40, len(pool))
+
+	a.Write([]byte("abc"))
+	b.Write([]byte("xyz"))
+	assert.Equal(t, []byte("abc"), a.Bytes())
+	assert.Equal(t, []byte("xyz"), b.Bytes())
+
+	_, err := NewChecked(8, WithAllocator(func(n int) []byte { return make([]byte, n-1) }))
+	assert.ErrorIs(t, err, ErrInvalidCapacity)
+}
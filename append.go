@@ -0,0 +1,61 @@
+package ringbuffer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// AppendTo consumes n bytes straight into b, growing it once and copying
+// each wrap segment a single time.
+func (rb *RingBuffer) AppendTo(b *bytes.Buffer, n int) (int, error) {
+	if err := rb.checkAppend(n); err != nil {
+		return 0, err
+	}
+	s1, s2 := rb.readSegments(0, n)
+	b.Grow(n)
+	b.Write(s1)
+	b.Write(s2)
+	if len(rb.readXform) > 0 {
+		applyTransforms(rb.readXform, b.Bytes()[b.Len()-n:], rb.consumed)
+	}
+	rb.advanceRead(n)
+	rb.traceOp(TraceRead, n)
+
+	return n, nil
+}
+
+// AppendString consumes n bytes straight into sb. With read transforms
+// configured the bytes go through a temporary copy, since a
+// strings.Builder cannot be modified in place.
+func (rb *RingBuffer) AppendString(sb *strings.Builder, n int) (int, error) {
+	if err := rb.checkAppend(n); err != nil {
+		return 0, err
+	}
+	sb.Grow(n)
+	if len(rb.readXform) > 0 {
+		tmp := make([]byte, n)
+		rb.copyOut(tmp, 0, n)
+		sb.Write(tmp)
+	} else {
+		s1, s2 := rb.readSegments(0, n)
+		sb.Write(s1)
+		sb.Write(s2)
+	}
+	rb.advanceRead(n)
+	rb.traceOp(TraceRead, n)
+
+	return n, nil
+}
+
+func (rb *RingBuffer) checkAppend(n int) error {
+	if rb.paused {
+		return ErrPaused
+	}
+	if n < 0 || rb.size < n {
+		return rb.violation(errors.New(fmt.Sprintf("invalid n. sz: %d, n: %d", rb.size, n)))
+	}
+
+	return rb.checkPeriod(n)
+}
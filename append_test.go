@@ -0,0 +1,58 @@
+package ringbuffer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AppendTo(t *testing.T) {
+
+	rb := NewRingBuffer(8)
+	rb.Write([]byte("xxxxxx"))
+	rb.DropOldest(6)
+	rb.Write([]byte("hello!"))
+
+	b := bytes.NewBufferString(">")
+	n, err := rb.AppendTo(b, 5)
+	assert.Nil(t, err)
+	assert.Equal(t, 5, n)
+	assert.Equal(t, ">hello", b.String())
+	assert.Equal(t, []byte("!"), rb.Bytes())
+
+	_, err = rb.AppendTo(b, 2)
+	assert.Error(t, err)
+}
+
+func Test_AppendString(t *testing.T) {
+
+	rb := NewRingBuffer(8)
+	rb.Write([]byte("xxxxx"))
+	rb.DropOldest(5)
+	rb.Write([]byte("wrapped"))
+
+	var sb strings.Builder
+	n, err := rb.AppendString(&sb, 7)
+	assert.Nil(t, err)
+	assert.Equal(t, 7, n)
+	assert.Equal(t, "wrapped", sb.String())
+	assert.Equal(t, 0, rb.Size())
+}
+
+func Test_AppendTransformed(t *testing.T) {
+
+	upper := TransformFunc(func(p []byte, seq uint64) {
+		copy(p, bytes.ToUpper(p))
+	})
+	rb := NewRingBuffer(8, WithReadTransform(upper))
+	rb.Write([]byte("abcdef"))
+
+	var b bytes.Buffer
+	rb.AppendTo(&b, 3)
+	var sb strings.Builder
+	rb.AppendString(&sb, 3)
+	assert.Equal(t, "ABC", b.String())
+	assert.Equal(t, "DEF", sb.String())
+}
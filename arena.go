@@ -0,0 +1,13 @@
+//go:build goexperiment.arenas
+
+package ringbuffer
+
+import "arena"
+
+// WithArena allocates the backing array from a, available when building
+// with GOEXPERIMENT=arenas. The buffer must not be used after a.Free.
+func WithArena(a *arena.Arena) Option {
+	return WithAllocator(func(n int) []byte {
+		return arena.MakeSlice[byte](a, n, n)
+	})
+}
@@ -0,0 +1,21 @@
+//go:build goexperiment.arenas
+
+package ringbuffer
+
+import (
+	"arena"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WithArena(t *testing.T) {
+
+	a := arena.NewArena()
+	defer a.Free()
+
+	rb := NewRingBuffer(32, WithArena(a))
+	rb.Write([]byte("session"))
+	assert.Equal(t, []byte("session"), rb.Bytes())
+	assert.Equal(t, 32, rb.Capacity())
+}
@@ -0,0 +1,178 @@
+package ringbuffer
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/drgolem/ringbuffer/raw"
+)
+
+// AudioRing hands PCM audio between an application goroutine and an
+// audio driver callback (PortAudio, miniaudio, ...). It is a
+// single-producer single-consumer ring synchronized only by atomic
+// counters: the callback-side methods FillOutput and PushInput never
+// lock, block or allocate, which makes them safe in real-time callbacks.
+// All transfers are whole frames.
+type AudioRing struct {
+	ring    *raw.Ring
+	format  PCMFormat
+	frame   int
+	silence byte
+	drift   *driftController
+	rs      Resampler
+	scratch []byte
+	fade    fadeState
+}
+
+func NewAudioRing(capacity int, f PCMFormat) (*AudioRing, error) {
+	if err := f.validate(); err != nil {
+		return nil, err
+	}
+	if capacity <= 0 || capacity > MaxCapacity || capacity%f.FrameSize() != 0 {
+		return nil, errors.New(fmt.Sprintf("invalid capacity %d for frame size %d", capacity, f.FrameSize()))
+	}
+	ar := &AudioRing{
+		ring:   raw.New(make([]byte, capacity)),
+		format: f,
+		frame:  f.FrameSize(),
+	}
+	if f.BitsPerSample == 8 && !f.Float {
+		ar.silence = 0x80
+	}
+
+	return ar, nil
+}
+
+func (ar *AudioRing) Capacity() int {
+	return ar.ring.Len()
+}
+
+// Size returns the number of buffered bytes. It is exact when called
+// from either side and a snapshot otherwise.
+func (ar *AudioRing) Size() int {
+	return ar.ring.Buffered()
+}
+
+// Write is the producer side for playback: it stores as many whole
+// frames of p as fit and returns the number of bytes stored.
+func (ar *AudioRing) Write(p []byte) int {
+	return ar.push(p)
+}
+
+// Read is the consumer side for capture: it copies as many whole frames
+// as are buffered into dst and returns the number of bytes copied.
+func (ar *AudioRing) Read(dst []byte) int {
+	return ar.pop(dst)
+}
+
+// FillOutput fills dst from the ring for a playback callback. Missing
+// frames are replaced with silence and reported as an underrun.
+func (ar *AudioRing) FillOutput(dst []byte) (filled int, underrun bool) {
+	filled = ar.pop(dst)
+	underrun = filled < len(dst)-len(dst)%ar.frame
+	if ar.fade.frames > 0 {
+		ar.applyFade(dst[:filled], underrun)
+	}
+	for i := filled; i < len(dst); i++ {
+		dst[i] = ar.silence
+	}
+
+	return filled, underrun
+}
+
+// PushInput stores src from a capture callback. Frames that do not fit
+// are dropped and reported as an overrun.
+func (ar *AudioRing) PushInput(src []byte) (pushed int, overrun bool) {
+	pushed = ar.push(src)
+
+	return pushed, pushed < len(src)-len(src)%ar.frame
+}
+
+func (ar *AudioRing) push(p []byte) int {
+	w := ar.ring.LoadWriteIndex()
+	free := ar.ring.Len() - int(w-ar.ring.LoadReadIndex())
+	n := len(p)
+	if n > free {
+		n = free
+	}
+	n -= n % ar.frame
+	if n == 0 {
+		return 0
+	}
+	s1, s2 := ar.ring.Slice(w, n)
+	m := copy(s1, p[:n])
+	copy(s2, p[m:n])
+	ar.ring.StoreWriteIndex(w + uint64(n))
+
+	return n
+}
+
+func (ar *AudioRing) pop(dst []byte) int {
+	if ar.rs != nil {
+		return ar.popResampled(dst)
+	}
+	c := ar.ring.LoadReadIndex()
+	avail := int(ar.ring.LoadWriteIndex() - c)
+	adj := 0
+	if ar.drift != nil {
+		adj = ar.drift.observe(avail)
+	}
+	if adj > 0 && avail > ar.frame {
+		c += uint64(ar.frame)
+		avail -= ar.frame
+		ar.drift.dropped()
+	}
+	n := avail
+	if n > len(dst) {
+		n = len(dst)
+	}
+	n -= n % ar.frame
+	if n == 0 {
+		ar.ring.StoreReadIndex(c)
+		return 0
+	}
+	take := n
+	if adj < 0 && n >= 2*ar.frame {
+		take -= ar.frame
+	}
+	s1, s2 := ar.ring.Slice(c, take)
+	m := copy(dst[:take], s1)
+	copy(dst[m:take], s2)
+	if take < n {
+		copy(dst[take:n], dst[take-ar.frame:take])
+		ar.drift.duplicated()
+	}
+	ar.ring.StoreReadIndex(c + uint64(take))
+	if ar.drift != nil {
+		ar.drift.advance(n / ar.frame)
+	}
+
+	return n
+}
+
+// Raw exposes the index-level ring underneath for custom coordination.
+// Moving its indices bypasses drift compensation, resampling and fades.
+func (ar *AudioRing) Raw() *raw.Ring {
+	return ar.ring
+}
+
+// BufferedDuration returns how much playback time is buffered.
+func (ar *AudioRing) BufferedDuration() time.Duration {
+	return ar.framesDuration(ar.Size() / ar.frame)
+}
+
+// ReadDuration reads up to d worth of whole frames into dst, bounded by
+// what is buffered and by len(dst), and returns the bytes read.
+func (ar *AudioRing) ReadDuration(d time.Duration, dst []byte) int {
+	frames := int(d * time.Duration(ar.format.SampleRate) / time.Second)
+	if n := frames * ar.frame; n < len(dst) {
+		dst = dst[:n]
+	}
+
+	return ar.Read(dst)
+}
+
+func (ar *AudioRing) framesDuration(frames int) time.Duration {
+	return time.Duration(frames) * time.Second / time.Duration(ar.format.SampleRate)
+}
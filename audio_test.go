@@ -0,0 +1,152 @@
+package ringbuffer
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var stereo16 = PCMFormat{SampleRate: 48000, Channels: 2, BitsPerSample: 16}
+
+func Test_AudioRingFillOutput(t *testing.T) {
+
+	ar, err := NewAudioRing(12, stereo16)
+	assert.Nil(t, err)
+
+	assert.Equal(t, 8, ar.Write([]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}))
+	out := make([]byte, 4)
+	filled, underrun := ar.FillOutput(out)
+	assert.Equal(t, 4, filled)
+	assert.False(t, underrun)
+
+	assert.Equal(t, 8, ar.Write([]byte{9, 10, 11, 12, 13, 14, 15, 16}))
+	out = make([]byte, 16)
+	filled, underrun = ar.FillOutput(out)
+	assert.Equal(t, 12, filled)
+	assert.True(t, underrun)
+	assert.Equal(t, []byte{5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 0, 0, 0, 0}, out)
+}
+
+func Test_AudioRingPushInput(t *testing.T) {
+
+	ar, _ := NewAudioRing(8, PCMFormat{SampleRate: 8000, Channels: 1, BitsPerSample: 8})
+	pushed, overrun := ar.PushInput([]byte{1, 2, 3, 4, 5})
+	assert.Equal(t, 5, pushed)
+	assert.False(t, overrun)
+	pushed, overrun = ar.PushInput([]byte{6, 7, 8, 9})
+	assert.Equal(t, 3, pushed)
+	assert.True(t, overrun)
+
+	out := make([]byte, 10)
+	assert.Equal(t, 8, ar.Read(out))
+	assert.Equal(t, []byte{1, 2, 3, 4, 5, 6, 7, 8}, out[:8])
+
+	filled, underrun := ar.FillOutput(out[:2])
+	assert.Equal(t, 0, filled)
+	assert.True(t, underrun)
+	assert.Equal(t, []byte{0x80, 0x80}, out[:2])
+}
+
+func Test_AudioRingInvalid(t *testing.T) {
+
+	_, err := NewAudioRing(10, stereo16)
+	assert.Error(t, err)
+	_, err = NewAudioRing(8, PCMFormat{})
+	assert.Error(t, err)
+	for _, bits := range []int{8, 16, 24} {
+		_, err = NewAudioRing(48, PCMFormat{SampleRate: 8000, Channels: 1, BitsPerSample: bits, Float: true})
+		assert.Error(t, err)
+	}
+	_, err = NewAudioRing(48, PCMFormat{SampleRate: 8000, Channels: 1, BitsPerSample: 64, Float: true})
+	assert.Nil(t, err)
+}
+
+func Test_AudioRingNoAllocs(t *testing.T) {
+
+	ar, _ := NewAudioRing(1024, stereo16)
+	in := make([]byte, 256)
+	out := make([]byte, 256)
+	allocs := testing.AllocsPerRun(100, func() {
+		ar.PushInput(in)
+		ar.FillOutput(out)
+	})
+	assert.Equal(t, 0.0, allocs)
+}
+
+func Test_AudioRingConcurrent(t *testing.T) {
+
+	ar, _ := NewAudioRing(64, PCMFormat{SampleRate: 8000, Channels: 1, BitsPerSample: 8})
+	const total = 100000
+	go func() {
+		buf := make([]byte, 7)
+		for i := 0; i < total; {
+			for j := range buf {
+				buf[j] = byte(i + j)
+			}
+			n := len(buf)
+			if total-i < n {
+				n = total - i
+			}
+			pushed, _ := ar.PushInput(buf[:n])
+			i += pushed
+			if pushed == 0 {
+				runtime.Gosched()
+			}
+		}
+	}()
+
+	out := make([]byte, 5)
+	for i := 0; i < total; {
+		n := ar.Read(out)
+		for j := 0; j < n; j++ {
+			if out[j] != byte(i+j) {
+				t.Fatalf("byte %d: got %d", i+j, out[j])
+			}
+		}
+		i += n
+		if n == 0 {
+			runtime.Gosched()
+		}
+	}
+}
+
+func Test_AudioRingDuration(t *testing.T) {
+
+	ar, _ := NewAudioRing(48000*4, stereo16)
+	ar.Write(make([]byte, 4800*4))
+	assert.Equal(t, 100*time.Millisecond, ar.BufferedDuration())
+
+	out := make([]byte, 48000*4)
+	n := ar.ReadDuration(20*time.Millisecond, out)
+	assert.Equal(t, 960*4, n)
+	assert.Equal(t, 80*time.Millisecond, ar.BufferedDuration())
+
+	n = ar.ReadDuration(20*time.Millisecond, out[:10])
+	assert.Equal(t, 8, n)
+
+	n = ar.ReadDuration(time.Second, out)
+	assert.Equal(t, (3840-2)*4, n)
+	assert.Equal(t, time.Duration(0), ar.BufferedDuration())
+}
+
+func Test_AudioRingRaw(t *testing.T) {
+
+	ar, _ := NewAudioRing(16, stereo16)
+	ar.Write([]byte{1, 2, 3, 4, 5, 6, 7, 8})
+
+	r := ar.Raw()
+	assert.Equal(t, 16, r.Len())
+	assert.Equal(t, 8, r.Buffered())
+
+	// consume one frame behind the safe API's back
+	rd := r.LoadReadIndex()
+	s1, _ := r.Slice(rd, 4)
+	assert.Equal(t, []byte{1, 2, 3, 4}, s1)
+	r.StoreReadIndex(rd + 4)
+
+	out := make([]byte, 8)
+	assert.Equal(t, 4, ar.Read(out))
+	assert.Equal(t, []byte{5, 6, 7, 8}, out[:4])
+}
@@ -0,0 +1,94 @@
+package ringbuffer
+
+type BackpressureLevel int
+
+const (
+	BackpressureOK BackpressureLevel = iota
+	BackpressureWarn
+	BackpressureCritical
+)
+
+func (l BackpressureLevel) String() string {
+	switch l {
+	case BackpressureOK:
+		return "ok"
+	case BackpressureWarn:
+		return "warn"
+	case BackpressureCritical:
+		return "critical"
+	}
+	return "unknown"
+}
+
+type BackpressureEvent struct {
+	Level    BackpressureLevel
+	Size     int
+	Capacity int
+}
+
+const backpressureQueueLen = 16
+
+type backpressure struct {
+	warn     int
+	critical int
+	level    BackpressureLevel
+	events   chan BackpressureEvent
+}
+
+// SetBackpressureBands enables backpressure events: the fill level is
+// warn once Size() reaches warn bytes and critical once it reaches
+// critical bytes.
+func (rb *RingBuffer) SetBackpressureBands(warn, critical int) {
+	if rb.bp == nil {
+		rb.bp = &backpressure{
+			events: make(chan BackpressureEvent, backpressureQueueLen),
+		}
+	}
+	rb.bp.warn = warn
+	rb.bp.critical = critical
+	rb.checkBackpressure()
+}
+
+// Backpressure returns the channel receiving an event whenever the fill
+// level moves to a different band. Sends never block the buffer: if the
+// receiver falls behind, the oldest queued events are discarded. It
+// returns nil until SetBackpressureBands is called.
+func (rb *RingBuffer) Backpressure() <-chan BackpressureEvent {
+	if rb.bp == nil {
+		return nil
+	}
+
+	return rb.bp.events
+}
+
+func (rb *RingBuffer) checkBackpressure() {
+	bp := rb.bp
+	if bp == nil {
+		return
+	}
+
+	level := BackpressureOK
+	switch {
+	case rb.size >= bp.critical:
+		level = BackpressureCritical
+	case rb.size >= bp.warn:
+		level = BackpressureWarn
+	}
+	if level == bp.level {
+		return
+	}
+	bp.level = level
+
+	ev := BackpressureEvent{Level: level, Size: rb.size, Capacity: rb.capacity}
+	for {
+		select {
+		case bp.events <- ev:
+			return
+		default:
+		}
+		select {
+		case <-bp.events:
+		default:
+		}
+	}
+}
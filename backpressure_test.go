@@ -0,0 +1,65 @@
+package ringbuffer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func nextEvent(ch <-chan BackpressureEvent) (BackpressureEvent, bool) {
+	select {
+	case ev := <-ch:
+		return ev, true
+	default:
+		return BackpressureEvent{}, false
+	}
+}
+
+func Test_Backpressure(t *testing.T) {
+
+	rb := NewRingBuffer(10)
+	assert.Nil(t, rb.Backpressure())
+
+	rb.SetBackpressureBands(5, 8)
+	ch := rb.Backpressure()
+	_, ok := nextEvent(ch)
+	assert.False(t, ok)
+
+	rb.Write([]byte{1, 2, 3, 4})
+	_, ok = nextEvent(ch)
+	assert.False(t, ok)
+
+	rb.Write([]byte{5})
+	ev, ok := nextEvent(ch)
+	assert.True(t, ok)
+	assert.Equal(t, BackpressureEvent{Level: BackpressureWarn, Size: 5, Capacity: 10}, ev)
+
+	rb.Write([]byte{6, 7, 8})
+	ev, _ = nextEvent(ch)
+	assert.Equal(t, BackpressureCritical, ev.Level)
+	assert.Equal(t, "critical", ev.Level.String())
+
+	rb.DropOldest(6)
+	ev, _ = nextEvent(ch)
+	assert.Equal(t, BackpressureOK, ev.Level)
+	assert.Equal(t, 2, ev.Size)
+}
+
+func Test_BackpressureSlowReceiver(t *testing.T) {
+
+	rb := NewRingBuffer(10)
+	rb.SetBackpressureBands(1, 10)
+
+	for i := 0; i < 2*backpressureQueueLen; i++ {
+		rb.WriteZeros(1)
+		rb.DropOldest(1)
+	}
+	ch := rb.Backpressure()
+	assert.Equal(t, backpressureQueueLen, len(ch))
+
+	var last BackpressureEvent
+	for len(ch) > 0 {
+		last, _ = nextEvent(ch)
+	}
+	assert.Equal(t, BackpressureOK, last.Level)
+}
@@ -0,0 +1,338 @@
+package ringbuffer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+var ErrClosed = errors.New("ring buffer closed")
+
+// Clock is the time source used for deadlines. Tests can substitute a
+// fake implementation to drive timeouts deterministically.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// stoppableClock is implemented by clocks whose After timers can be
+// cancelled, such as fakeclock.Clock. Timers abandoned early are stopped
+// so a fake clock does not keep counting them as waiters.
+type stoppableClock interface {
+	Stop(ch <-chan time.Time) bool
+}
+
+// newTimer starts a timer for d on clock and returns its channel and a
+// function that stops it. The system clock is backed by time.NewTimer so
+// an abandoned wait does not leave a timer running until it fires;
+// stoppable clocks cancel through Stop. Callers stop the timer on every
+// path that stops waiting on it.
+func newTimer(clock Clock, d time.Duration) (<-chan time.Time, func() bool) {
+	if _, ok := clock.(systemClock); ok {
+		t := time.NewTimer(d)
+		return t.C, t.Stop
+	}
+	ch := clock.After(d)
+	if sc, ok := clock.(stoppableClock); ok {
+		return ch, func() bool { return sc.Stop(ch) }
+	}
+
+	return ch, func() bool { return false }
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// BlockingRingBuffer is a RingBuffer safe for concurrent use whose Read
+// and Write wait for data or space, with net.Conn-style deadlines.
+type BlockingRingBuffer struct {
+	mu            sync.Mutex
+	rb            *RingBuffer
+	clock         Clock
+	changed       chan struct{}
+	closed        bool
+	readDeadline  time.Time
+	writeDeadline time.Time
+
+	lastRead  time.Time
+	lastWrite time.Time
+	// pendingSince is when the buffer last went from empty to holding
+	// data.
+	pendingSince time.Time
+	readWaiters  int
+}
+
+// NewBlockingRingBuffer creates a blocking buffer. A nil clock selects
+// the system clock.
+func NewBlockingRingBuffer(capacity int, clock Clock) *BlockingRingBuffer {
+	if clock == nil {
+		clock = systemClock{}
+	}
+
+	now := clock.Now()
+
+	return &BlockingRingBuffer{
+		rb:        NewRingBuffer(capacity),
+		clock:     clock,
+		changed:   make(chan struct{}),
+		lastRead:  now,
+		lastWrite: now,
+	}
+}
+
+func (b *BlockingRingBuffer) Capacity() int {
+	return b.rb.Capacity()
+}
+
+func (b *BlockingRingBuffer) Size() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.rb.Size()
+}
+
+// Read waits until data is available and reads up to len(dst) bytes. It
+// returns io.EOF once the buffer is closed and drained, and
+// os.ErrDeadlineExceeded when the read deadline passes.
+func (b *BlockingRingBuffer) Read(dst []byte) (int, error) {
+	return b.ReadContext(context.Background(), dst)
+}
+
+// ReadContext is Read that also gives up with ctx.Err() when ctx ends.
+func (b *BlockingRingBuffer) ReadContext(ctx context.Context, dst []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for b.rb.Size() == 0 {
+		if b.closed {
+			return 0, io.EOF
+		}
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		b.readWaiters++
+		err := b.waitDone(b.readDeadline, ctx.Done())
+		b.readWaiters--
+		if err != nil {
+			return 0, err
+		}
+	}
+	n, err := b.rb.Read(dst)
+	b.lastRead = b.clock.Now()
+	b.broadcast()
+
+	return n, err
+}
+
+// Write waits for space until all of data is written. On deadline or
+// Close it returns the count written so far with the error.
+func (b *BlockingRingBuffer) Write(data []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	written := 0
+	for written < len(data) {
+		if b.closed {
+			return written, ErrClosed
+		}
+		if b.rb.AvailableWriteSize() == 0 {
+			if err := b.wait(b.writeDeadline); err != nil {
+				return written, err
+			}
+			continue
+		}
+		written += b.writeSome(data[written:])
+	}
+
+	return written, nil
+}
+
+// writeSome writes what fits of data and wakes waiters. It must be
+// called with b.mu held.
+func (b *BlockingRingBuffer) writeSome(data []byte) int {
+	wasEmpty := b.rb.Size() == 0
+	n := b.rb.WriteAvailable(data)
+	b.lastWrite = b.clock.Now()
+	if wasEmpty && n > 0 {
+		b.pendingSince = b.lastWrite
+	}
+	b.broadcast()
+
+	return n
+}
+
+// TryRead reads up to len(dst) bytes without ever blocking, for
+// real-time callbacks that must not wait behind another goroutine. ok is
+// false when the buffer is busy or the read fails; n is 0 when it is
+// empty.
+func (b *BlockingRingBuffer) TryRead(dst []byte) (n int, ok bool) {
+	if !b.mu.TryLock() {
+		return 0, false
+	}
+	defer b.mu.Unlock()
+
+	if b.rb.Size() == 0 {
+		return 0, true
+	}
+	n, err := b.rb.Read(dst)
+	if err != nil {
+		return 0, false
+	}
+	b.lastRead = b.clock.Now()
+	b.broadcast()
+
+	return n, true
+}
+
+// TryWrite writes what fits of data without ever blocking. ok is false
+// when the buffer is busy or closed; n is short when space runs out.
+func (b *BlockingRingBuffer) TryWrite(data []byte) (n int, ok bool) {
+	if !b.mu.TryLock() {
+		return 0, false
+	}
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return 0, false
+	}
+	if len(data) == 0 || b.rb.AvailableWriteSize() == 0 {
+		return 0, true
+	}
+
+	return b.writeSome(data), true
+}
+
+// Close makes further writes fail; readers drain what is left and then
+// get io.EOF.
+func (b *BlockingRingBuffer) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	b.broadcast()
+
+	return nil
+}
+
+func (b *BlockingRingBuffer) SetDeadline(t time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.readDeadline = t
+	b.writeDeadline = t
+	b.broadcast()
+
+	return nil
+}
+
+func (b *BlockingRingBuffer) SetReadDeadline(t time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.readDeadline = t
+	b.broadcast()
+
+	return nil
+}
+
+func (b *BlockingRingBuffer) SetWriteDeadline(t time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.writeDeadline = t
+	b.broadcast()
+
+	return nil
+}
+
+// wait releases the lock until the buffer state changes or the deadline
+// passes. It must be called with b.mu held and returns with it held.
+func (b *BlockingRingBuffer) wait(deadline time.Time) error {
+	return b.waitDone(deadline, nil)
+}
+
+// waitDone is wait that also returns early when done is closed.
+func (b *BlockingRingBuffer) waitDone(deadline time.Time, done <-chan struct{}) error {
+	var timeout <-chan time.Time
+	if !deadline.IsZero() {
+		d := deadline.Sub(b.clock.Now())
+		if d <= 0 {
+			return os.ErrDeadlineExceeded
+		}
+		var stop func() bool
+		timeout, stop = newTimer(b.clock, d)
+		defer stop()
+	}
+	changed := b.changed
+
+	b.mu.Unlock()
+	select {
+	case <-changed:
+	case <-timeout:
+	case <-done:
+	}
+	b.mu.Lock()
+
+	return nil
+}
+
+// broadcast wakes every waiter. It must be called with b.mu held.
+func (b *BlockingRingBuffer) broadcast() {
+	close(b.changed)
+	b.changed = make(chan struct{})
+}
+
+// ReadWithin collects data into dst for up to d, returning early only
+// when dst is full or the buffer is closed. It returns whatever arrived,
+// with os.ErrDeadlineExceeded if nothing did (io.EOF if closed). A failed
+// read returns its error with the bytes read before it.
+func (b *BlockingRingBuffer) ReadWithin(d time.Duration, dst []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	deadline := b.clock.Now().Add(d)
+	read := 0
+	for read < len(dst) {
+		if b.rb.Size() > 0 {
+			n := b.rb.Size()
+			if n > len(dst)-read {
+				n = len(dst) - read
+			}
+			if _, err := b.rb.ReadExact(n, dst[read:]); err != nil {
+				return read, err
+			}
+			read += n
+			b.lastRead = b.clock.Now()
+			b.broadcast()
+			continue
+		}
+		if b.closed {
+			break
+		}
+		b.readWaiters++
+		err := b.wait(deadline)
+		b.readWaiters--
+		if err != nil {
+			break
+		}
+	}
+
+	switch {
+	case read > 0 || len(dst) == 0:
+		return read, nil
+	case b.closed:
+		return 0, io.EOF
+	}
+
+	return 0, os.ErrDeadlineExceeded
+}
@@ -0,0 +1,184 @@
+package ringbuffer
+
+import (
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/drgolem/ringbuffer/fakeclock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_BlockingReadWrite(t *testing.T) {
+
+	b := NewBlockingRingBuffer(4, nil)
+	assert.Equal(t, 4, b.Capacity())
+
+	data := make([]byte, 100)
+	for i := range data {
+		data[i] = byte(i)
+	}
+
+	go func() {
+		b.Write(data)
+		b.Close()
+	}()
+
+	got, err := io.ReadAll(b)
+	assert.Nil(t, err)
+	assert.Equal(t, data, got)
+
+	n, err := b.Write([]byte{1})
+	assert.ErrorIs(t, err, ErrClosed)
+	assert.Equal(t, 0, n)
+}
+
+func Test_BlockingReadDeadline(t *testing.T) {
+
+	clock := fakeclock.New(time.Unix(1000, 0))
+	b := NewBlockingRingBuffer(4, clock)
+	b.SetReadDeadline(clock.Now().Add(time.Second))
+
+	done := make(chan error)
+	go func() {
+		_, err := b.Read(make([]byte, 1))
+		done <- err
+	}()
+
+	clock.BlockUntil(1)
+	clock.Advance(500 * time.Millisecond)
+	select {
+	case <-done:
+		t.Fatal("read returned before deadline")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	clock.Advance(500 * time.Millisecond)
+	assert.ErrorIs(t, <-done, os.ErrDeadlineExceeded)
+}
+
+func Test_BlockingWriteDeadline(t *testing.T) {
+
+	clock := fakeclock.New(time.Unix(1000, 0))
+	b := NewBlockingRingBuffer(4, clock)
+	b.SetWriteDeadline(clock.Now())
+
+	n, err := b.Write([]byte{1, 2, 3, 4, 5, 6})
+	assert.ErrorIs(t, err, os.ErrDeadlineExceeded)
+	assert.Equal(t, 4, n)
+
+	b.SetDeadline(time.Time{})
+	out := make([]byte, 4)
+	n, err = b.Read(out)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{1, 2, 3, 4}, out[:n])
+}
+
+func Test_BlockingReadWithin(t *testing.T) {
+
+	clock := fakeclock.New(time.Unix(1000, 0))
+	b := NewBlockingRingBuffer(8, clock)
+
+	type result struct {
+		n   int
+		err error
+	}
+	out := make([]byte, 4)
+	done := make(chan result)
+	read := func() {
+		n, err := b.ReadWithin(time.Second, out)
+		done <- result{n, err}
+	}
+
+	go read()
+	clock.BlockUntil(1)
+	b.Write([]byte{1, 2})
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+	r := <-done
+	assert.Nil(t, r.err)
+	assert.Equal(t, []byte{1, 2}, out[:r.n])
+
+	go read()
+	clock.BlockUntil(1)
+	b.Write([]byte{3, 4, 5, 6, 7})
+	r = <-done
+	assert.Nil(t, r.err)
+	assert.Equal(t, []byte{3, 4, 5, 6}, out[:r.n])
+
+	b.Read(out)
+	// the timer of the previous read, which returned early, was stopped
+	assert.Equal(t, 0, clock.Waiters())
+	go read()
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+	r = <-done
+	assert.ErrorIs(t, r.err, os.ErrDeadlineExceeded)
+	assert.Equal(t, 0, r.n)
+
+	b.Close()
+	n, err := b.ReadWithin(time.Second, out)
+	assert.ErrorIs(t, err, io.EOF)
+	assert.Equal(t, 0, n)
+}
+
+func Test_BlockingReadWithinError(t *testing.T) {
+
+	b := NewBlockingRingBuffer(8, fakeclock.New(time.Unix(1000, 0)))
+	b.Write([]byte{1, 2})
+	b.rb.Pause()
+
+	n, err := b.ReadWithin(time.Second, make([]byte, 4))
+	assert.ErrorIs(t, err, ErrPaused)
+	assert.Equal(t, 0, n)
+	assert.Equal(t, 2, b.Size())
+}
+
+func Test_BlockingTryReadWrite(t *testing.T) {
+
+	b := NewBlockingRingBuffer(4, nil)
+	out := make([]byte, 4)
+
+	n, ok := b.TryRead(out)
+	assert.True(t, ok)
+	assert.Equal(t, 0, n)
+
+	n, ok = b.TryWrite([]byte{1, 2, 3, 4, 5})
+	assert.True(t, ok)
+	assert.Equal(t, 4, n)
+	n, ok = b.TryWrite([]byte{6})
+	assert.True(t, ok)
+	assert.Equal(t, 0, n)
+
+	// never waits behind a lock holder
+	b.mu.Lock()
+	_, ok = b.TryRead(out)
+	assert.False(t, ok)
+	_, ok = b.TryWrite([]byte{6})
+	assert.False(t, ok)
+	b.mu.Unlock()
+
+	n, ok = b.TryRead(out[:3])
+	assert.True(t, ok)
+	assert.Equal(t, []byte{1, 2, 3}, out[:n])
+
+	b.Close()
+	_, ok = b.TryWrite([]byte{6})
+	assert.False(t, ok)
+	n, ok = b.TryRead(out)
+	assert.True(t, ok)
+	assert.Equal(t, []byte{4}, out[:n])
+}
+
+func Test_NewTimerStops(t *testing.T) {
+
+	_, stop := newTimer(systemClock{}, time.Hour)
+	assert.True(t, stop())
+
+	clock := fakeclock.New(time.Unix(1000, 0))
+	_, stop = newTimer(clock, time.Hour)
+	assert.Equal(t, 1, clock.Waiters())
+	assert.True(t, stop())
+	assert.Equal(t, 0, clock.Waiters())
+}
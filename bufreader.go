@@ -0,0 +1,122 @@
+package ringbuffer
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+// BufReader mirrors the bufio.Reader methods parsers rely on, served
+// directly from the ring, so code written against bufio can switch over
+// and keep the ring's writable side. With no underlying reader to fill
+// from, running out of buffered data is reported as io.EOF; more data may
+// become readable after further writes.
+type BufReader struct {
+	rb      *RingBuffer
+	scratch []byte
+}
+
+func (rb *RingBuffer) BufReader() *BufReader {
+	return &BufReader{rb: rb}
+}
+
+func (br *BufReader) Buffered() int {
+	return br.rb.Size()
+}
+
+// view returns the first n buffered bytes as one slice, aliasing the
+// ring when they are contiguous and untransformed. Like bufio, it is
+// valid until the next read from br or write to the ring.
+func (br *BufReader) view(n int) []byte {
+	s1, s2 := br.rb.readSegments(0, n)
+	if len(s2) == 0 && len(br.rb.readXform) == 0 {
+		return s1
+	}
+	if cap(br.scratch) < n {
+		br.scratch = make([]byte, n)
+	}
+	p := br.scratch[:n]
+	br.rb.copyOut(p, 0, n)
+
+	return p
+}
+
+// Peek returns the next n bytes without consuming them. It returns
+// bufio.ErrBufferFull if n exceeds the capacity and io.EOF, with what is
+// buffered, if fewer than n bytes are buffered.
+func (br *BufReader) Peek(n int) ([]byte, error) {
+	if n < 0 {
+		return nil, bufio.ErrNegativeCount
+	}
+	if n > br.rb.Capacity() {
+		return br.view(br.rb.Size()), bufio.ErrBufferFull
+	}
+	if n > br.rb.Size() {
+		return br.view(br.rb.Size()), io.EOF
+	}
+
+	return br.view(n), nil
+}
+
+// Discard skips the next n bytes, returning io.EOF if fewer were
+// buffered.
+func (br *BufReader) Discard(n int) (int, error) {
+	if n < 0 {
+		return 0, bufio.ErrNegativeCount
+	}
+	var err error
+	if n > br.rb.Size() {
+		n = br.rb.Size()
+		err = io.EOF
+	}
+	if e := br.rb.DropOldest(n); e != nil {
+		return 0, e
+	}
+
+	return n, err
+}
+
+// ReadSlice reads until the first occurrence of delim and returns the
+// bytes including it. Without delim it returns everything buffered, with
+// bufio.ErrBufferFull if the ring is full and io.EOF otherwise. In period
+// mode a slice that is not a whole number of periods cannot be consumed
+// and fails with ErrNotPeriodAligned, leaving the data buffered.
+func (br *BufReader) ReadSlice(delim byte) ([]byte, error) {
+	if br.rb.paused {
+		return nil, ErrPaused
+	}
+	size := br.rb.Size()
+	p := br.view(size)
+	var err error
+	if i := bytes.IndexByte(p, delim); i >= 0 {
+		p = p[:i+1]
+	} else if br.rb.IsFull() {
+		err = bufio.ErrBufferFull
+	} else {
+		err = io.EOF
+	}
+	if e := br.rb.DropOldest(len(p)); e != nil {
+		return nil, e
+	}
+
+	return p, err
+}
+
+// Read reads up to len(p) bytes, returning io.EOF when nothing is
+// buffered.
+func (br *BufReader) Read(p []byte) (int, error) {
+	return br.rb.Read(p)
+}
+
+func (br *BufReader) ReadByte() (byte, error) {
+	var b [1]byte
+	if _, err := br.Read(b[:]); err != nil {
+		return 0, err
+	}
+
+	return b[0], nil
+}
+
+func (br *BufReader) UnreadByte() error {
+	return br.rb.UnreadByte()
+}
@@ -0,0 +1,87 @@
+package ringbuffer
+
+import (
+	"bufio"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_BufReaderPeekDiscard(t *testing.T) {
+
+	rb := NewRingBuffer(8)
+	rb.Write([]byte("xxxxxx"))
+	rb.DropOldest(6)
+	rb.Write([]byte("abcdef"))
+	br := rb.BufReader()
+
+	p, err := br.Peek(4)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("abcd"), p)
+	assert.Equal(t, 6, br.Buffered())
+
+	p, err = br.Peek(7)
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, []byte("abcdef"), p)
+	_, err = br.Peek(9)
+	assert.Equal(t, bufio.ErrBufferFull, err)
+
+	n, err := br.Discard(2)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, n)
+	n, err = br.Discard(10)
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, 4, n)
+	assert.Equal(t, 0, br.Buffered())
+}
+
+func Test_BufReaderReadSlice(t *testing.T) {
+
+	rb := NewRingBuffer(8)
+	br := rb.BufReader()
+	rb.Write([]byte("ab\ncd"))
+
+	line, err := br.ReadSlice('\n')
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("ab\n"), line)
+
+	line, err = br.ReadSlice('\n')
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, []byte("cd"), line)
+
+	rb.Write([]byte("12345678"))
+	_, err = br.ReadSlice('\n')
+	assert.Equal(t, bufio.ErrBufferFull, err)
+}
+
+func Test_BufReaderBytes(t *testing.T) {
+
+	rb := NewRingBuffer(8)
+	br := rb.BufReader()
+	rb.Write([]byte("xy"))
+
+	b, err := br.ReadByte()
+	assert.Nil(t, err)
+	assert.Equal(t, byte('x'), b)
+	assert.Nil(t, br.UnreadByte())
+
+	out := make([]byte, 4)
+	n, err := br.Read(out)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("xy"), out[:n])
+	_, err = br.Read(out)
+	assert.Equal(t, io.EOF, err)
+}
+
+func Test_BufReaderReadSlicePeriod(t *testing.T) {
+
+	rb := NewRingBuffer(8, WithPeriod(2))
+	rb.Write([]byte("ab\nc"))
+	br := rb.BufReader()
+
+	p, err := br.ReadSlice('\n')
+	assert.ErrorIs(t, err, ErrNotPeriodAligned)
+	assert.Nil(t, p)
+	assert.Equal(t, 4, br.Buffered())
+}
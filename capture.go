@@ -0,0 +1,61 @@
+//go:build !tinygo
+
+package ringbuffer
+
+import (
+	"os/exec"
+	"sync"
+)
+
+// TailBuffer is a concurrency-safe writer that retains only the last
+// Capacity bytes written to it, for supervising long-running output
+// without unbounded growth.
+type TailBuffer struct {
+	mu sync.Mutex
+	rb *RingBuffer
+}
+
+func NewTailBuffer(capacity int) *TailBuffer {
+	return &TailBuffer{rb: NewRingBuffer(capacity, WithOverwrite())}
+}
+
+// Write never fails; older bytes are discarded to make room.
+func (tb *TailBuffer) Write(p []byte) (int, error) {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	return tb.rb.Write(p)
+}
+
+// Tail returns a copy of the newest min(n, retained) bytes.
+func (tb *TailBuffer) Tail(n int) []byte {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	if n > tb.rb.Size() {
+		n = tb.rb.Size()
+	}
+	out := make([]byte, n)
+	tb.rb.ReadLatest(out)
+
+	return out
+}
+
+// Lost returns how many bytes have been discarded.
+func (tb *TailBuffer) Lost() uint64 {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	return tb.rb.LostBytes()
+}
+
+// CaptureOutput points cmd's stdout and stderr at tail buffers retaining
+// the last limit bytes of each. It must be called before cmd starts.
+func CaptureOutput(cmd *exec.Cmd, limit int) (stdout, stderr *TailBuffer) {
+	stdout = NewTailBuffer(limit)
+	stderr = NewTailBuffer(limit)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	return stdout, stderr
+}
@@ -0,0 +1,36 @@
+//go:build !tinygo
+
+package ringbuffer
+
+import (
+	"os/exec"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TailBuffer(t *testing.T) {
+
+	tb := NewTailBuffer(8)
+	tb.Write([]byte("hello "))
+	tb.Write([]byte("world"))
+
+	assert.Equal(t, []byte("lo world"), tb.Tail(100))
+	assert.Equal(t, []byte("rld"), tb.Tail(3))
+	assert.Equal(t, uint64(3), tb.Lost())
+}
+
+func Test_CaptureOutput(t *testing.T) {
+	if runtime.GOOS == "windows" || runtime.GOARCH == "wasm" {
+		t.Skip("needs a POSIX shell and subprocesses")
+	}
+
+	cmd := exec.Command("sh", "-c", "for i in 1 2 3 4 5; do echo line$i; done; echo oops >&2")
+	stdout, stderr := CaptureOutput(cmd, 12)
+	assert.Nil(t, cmd.Run())
+
+	assert.Equal(t, []byte("line4\nline5\n"), stdout.Tail(100))
+	assert.Equal(t, uint64(18), stdout.Lost())
+	assert.Equal(t, []byte("oops\n"), stderr.Tail(100))
+}
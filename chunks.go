@@ -0,0 +1,141 @@
+package ringbuffer
+
+import (
+	"errors"
+	"io"
+)
+
+var ErrNotChunked = errors.New("ring buffer not in chunk mode")
+
+// chunkEntry records where a chunk ends in the stream and the metadata
+// word it was written with.
+type chunkEntry struct {
+	end  uint64
+	meta uint32
+}
+
+// WithChunks remembers the boundary of every write so that ReadChunk can
+// return exactly one write's worth of data. Byte-oriented reads still
+// work; a partial read shortens the oldest chunk.
+func WithChunks() Option {
+	return func(rb *RingBuffer) {
+		rb.chunked = true
+	}
+}
+
+// Chunks reports the number of buffered chunks.
+func (rb *RingBuffer) Chunks() int {
+	return len(rb.chunkEnds) - rb.chunkHead
+}
+
+// NextChunkSize returns the size of the oldest buffered chunk; ok is false
+// when there is none.
+func (rb *RingBuffer) NextChunkSize() (int, bool) {
+	if rb.Chunks() == 0 {
+		return 0, false
+	}
+
+	return int(rb.chunkEnds[rb.chunkHead].end - rb.consumed), true
+}
+
+// ReadChunk reads the oldest chunk into dst. It returns ErrEmpty when no
+// chunk is buffered and io.ErrShortBuffer, consuming nothing, when dst
+// cannot hold the whole chunk.
+func (rb *RingBuffer) ReadChunk(dst []byte) (int, error) {
+	if !rb.chunked {
+		return 0, rb.violation(ErrNotChunked)
+	}
+	n, ok := rb.NextChunkSize()
+	if !ok {
+		return 0, ErrEmpty
+	}
+	if len(dst) < n {
+		return 0, io.ErrShortBuffer
+	}
+
+	return rb.ReadExact(n, dst)
+}
+
+// popChunks moves the boundaries of fully consumed chunks into the
+// history kept in front of chunkHead, so that Unread can restore them,
+// and forgets history that is no longer recoverable.
+func (rb *RingBuffer) popChunks() {
+	for rb.chunkHead < len(rb.chunkEnds) && seqReached(rb.consumed, rb.chunkEnds[rb.chunkHead].end) {
+		rb.chunkHead++
+	}
+
+	oldest := rb.consumed - uint64(rb.unread)
+	i := 0
+	for i < rb.chunkHead && seqReached(oldest, rb.chunkEnds[i].end) {
+		i++
+	}
+	if i > 0 {
+		k := copy(rb.chunkEnds, rb.chunkEnds[i:])
+		rb.chunkEnds = rb.chunkEnds[:k]
+		rb.chunkHead -= i
+	}
+}
+
+func (rb *RingBuffer) unpopChunks() {
+	for rb.chunkHead > 0 && !seqReached(rb.consumed, rb.chunkEnds[rb.chunkHead-1].end) {
+		rb.chunkHead--
+	}
+}
+
+// retractChunks drops the boundaries beyond the write position after a
+// TruncateNewest; a chunk cut in the middle ends at the new position and
+// keeps its metadata.
+func (rb *RingBuffer) retractChunks() {
+	k := len(rb.chunkEnds)
+	for k > rb.chunkHead && !seqReached(rb.written, rb.chunkEnds[k-1].end) {
+		k--
+	}
+	if k < len(rb.chunkEnds) && rb.size > 0 && (k == rb.chunkHead || rb.chunkEnds[k-1].end != rb.written) {
+		rb.chunkEnds[k].end = rb.written
+		k++
+	}
+	rb.chunkEnds = rb.chunkEnds[:k]
+}
+
+// WriteChunk writes data as one chunk tagged with meta, a caller-defined
+// word for flags, stream ids or marker bits.
+func (rb *RingBuffer) WriteChunk(data []byte, meta uint32) (int, error) {
+	if !rb.chunked {
+		return 0, rb.violation(ErrNotChunked)
+	}
+	rb.chunkMeta = meta
+	n, err := rb.Write(data)
+	rb.chunkMeta = 0
+
+	return n, err
+}
+
+// NextChunkMeta returns the metadata word of the oldest buffered chunk.
+func (rb *RingBuffer) NextChunkMeta() (uint32, bool) {
+	if rb.Chunks() == 0 {
+		return 0, false
+	}
+
+	return rb.chunkEnds[rb.chunkHead].meta, true
+}
+
+// ReadChunkMeta is ReadChunk that also returns the chunk's metadata word.
+func (rb *RingBuffer) ReadChunkMeta(dst []byte) (int, uint32, error) {
+	meta, _ := rb.NextChunkMeta()
+	n, err := rb.ReadChunk(dst)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return n, meta, nil
+}
+
+// mergeNewestChunks joins the two newest buffered chunks into one.
+func (rb *RingBuffer) mergeNewestChunks() {
+	k := len(rb.chunkEnds)
+	if k-rb.chunkHead < 2 {
+		return
+	}
+	rb.chunkEnds[k-2].end = rb.chunkEnds[k-1].end
+	rb.chunkEnds = rb.chunkEnds[:k-1]
+}
@@ -0,0 +1,144 @@
+package ringbuffer
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ReadChunk(t *testing.T) {
+
+	rb := NewRingBuffer(8, WithChunks())
+	out := make([]byte, 8)
+
+	_, err := rb.ReadChunk(out)
+	assert.ErrorIs(t, err, ErrEmpty)
+
+	rb.Write([]byte{1, 2, 3})
+	rb.Write([]byte{4})
+	rb.Write(nil)
+	rb.Write([]byte{5, 6})
+	assert.Equal(t, 3, rb.Chunks())
+
+	n, err := rb.ReadChunk(out[:2])
+	assert.ErrorIs(t, err, io.ErrShortBuffer)
+	assert.Equal(t, 0, n)
+
+	n, err = rb.ReadChunk(out)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, out[:n])
+
+	rb.Write([]byte{7, 8, 9, 10, 11})
+	n, err = rb.ReadChunk(out)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{4}, out[:n])
+
+	// a partial byte read shortens the oldest chunk
+	rb.ReadExact(1, out)
+	sz, ok := rb.NextChunkSize()
+	assert.True(t, ok)
+	assert.Equal(t, 1, sz)
+	n, _ = rb.ReadChunk(out)
+	assert.Equal(t, []byte{6}, out[:n])
+
+	// unread restores chunk boundaries
+	rb.Unread(2)
+	n, _ = rb.ReadChunk(out)
+	assert.Equal(t, []byte{5, 6}, out[:n])
+	rb.Unread(3)
+	assert.Equal(t, 3, rb.Chunks())
+	n, _ = rb.ReadChunk(out)
+	assert.Equal(t, []byte{4}, out[:n])
+	rb.ReadExact(1, out)
+	rb.Unread(1)
+	n, _ = rb.ReadChunk(out)
+	assert.Equal(t, []byte{5, 6}, out[:n])
+
+	n, _ = rb.ReadChunk(out)
+	assert.Equal(t, []byte{7, 8, 9, 10, 11}, out[:n])
+	assert.Equal(t, 0, rb.Chunks())
+}
+
+func Test_ChunkTruncate(t *testing.T) {
+
+	rb := NewRingBuffer(8, WithChunks())
+	out := make([]byte, 8)
+
+	rb.Write([]byte{1, 2})
+	rb.Write([]byte{3, 4, 5})
+	rb.Write([]byte{6})
+
+	rb.TruncateNewest(3)
+	assert.Equal(t, 2, rb.Chunks())
+
+	rb.Write([]byte{7})
+	n, _ := rb.ReadChunk(out)
+	assert.Equal(t, []byte{1, 2}, out[:n])
+	n, _ = rb.ReadChunk(out)
+	assert.Equal(t, []byte{3}, out[:n])
+	n, _ = rb.ReadChunk(out)
+	assert.Equal(t, []byte{7}, out[:n])
+}
+
+func Test_ChunkOverwrite(t *testing.T) {
+
+	rb := NewRingBuffer(4, WithChunks(), WithOverwrite())
+	out := make([]byte, 4)
+
+	rb.Write([]byte{1, 2})
+	rb.Write([]byte{3, 4})
+	rb.Write([]byte{5})
+
+	n, _ := rb.ReadChunk(out)
+	assert.Equal(t, []byte{2}, out[:n])
+	n, _ = rb.ReadChunk(out)
+	assert.Equal(t, []byte{3, 4}, out[:n])
+
+	rb.Write([]byte{6, 7, 8, 9, 10})
+	assert.Equal(t, 1, rb.Chunks())
+	n, _ = rb.ReadChunk(out)
+	assert.Equal(t, []byte{7, 8, 9, 10}, out[:n])
+}
+
+func Test_ReadChunkNotChunked(t *testing.T) {
+
+	rb := NewRingBuffer(4)
+	_, err := rb.ReadChunk(make([]byte, 4))
+	assert.ErrorIs(t, err, ErrNotChunked)
+}
+
+func Test_ChunkMeta(t *testing.T) {
+
+	rb := NewRingBuffer(8, WithChunks())
+	out := make([]byte, 8)
+
+	rb.WriteChunk([]byte{1, 2}, 0x80000001)
+	rb.Write([]byte{3})
+	rb.WriteChunk([]byte{4, 5, 6}, 7)
+
+	meta, ok := rb.NextChunkMeta()
+	assert.True(t, ok)
+	assert.Equal(t, uint32(0x80000001), meta)
+
+	n, meta, err := rb.ReadChunkMeta(out)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{1, 2}, out[:n])
+	assert.Equal(t, uint32(0x80000001), meta)
+
+	n, meta, _ = rb.ReadChunkMeta(out)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, uint32(0), meta)
+
+	rb.TruncateNewest(1)
+	n, meta, _ = rb.ReadChunkMeta(out)
+	assert.Equal(t, []byte{4, 5}, out[:n])
+	assert.Equal(t, uint32(7), meta)
+
+	rb.Unread(2)
+	meta, _ = rb.NextChunkMeta()
+	assert.Equal(t, uint32(7), meta)
+
+	_, _, err = rb.ReadChunkMeta(out[:1])
+	assert.Equal(t, io.ErrShortBuffer, err)
+}
@@ -0,0 +1,133 @@
+package ringbuffer
+
+import (
+	"bytes"
+	"compress/flate"
+	"io"
+)
+
+// Codec transforms each chunk on its way into and out of the storage.
+// Encode and Decode append their output to dst and return it.
+type Codec interface {
+	Encode(dst, src []byte) ([]byte, error)
+	Decode(dst, src []byte) ([]byte, error)
+}
+
+// FlateCodec is a DEFLATE Codec. It reuses its compressor between calls
+// and is not safe for concurrent use.
+type FlateCodec struct {
+	Level int
+	w     *flate.Writer
+	r     io.ReadCloser
+	buf   bytes.Buffer
+}
+
+func NewFlateCodec(level int) *FlateCodec {
+	return &FlateCodec{Level: level}
+}
+
+func (c *FlateCodec) Encode(dst, src []byte) ([]byte, error) {
+	out := bytes.NewBuffer(dst)
+	if c.w == nil {
+		w, err := flate.NewWriter(out, c.Level)
+		if err != nil {
+			return dst, err
+		}
+		c.w = w
+	} else {
+		c.w.Reset(out)
+	}
+	if _, err := c.w.Write(src); err != nil {
+		return dst, err
+	}
+	if err := c.w.Close(); err != nil {
+		return dst, err
+	}
+
+	return out.Bytes(), nil
+}
+
+func (c *FlateCodec) Decode(dst, src []byte) ([]byte, error) {
+	if c.r == nil {
+		c.r = flate.NewReader(bytes.NewReader(src))
+	} else if err := c.r.(flate.Resetter).Reset(bytes.NewReader(src), nil); err != nil {
+		return dst, err
+	}
+	out := bytes.NewBuffer(dst)
+	if _, err := out.ReadFrom(c.r); err != nil {
+		return dst, err
+	}
+
+	return out.Bytes(), nil
+}
+
+// CompressedRing stores every write as one encoded chunk so that highly
+// compressible streams take less buffer memory. Reads return whole
+// decoded chunks.
+type CompressedRing struct {
+	rb    *RingBuffer
+	codec Codec
+	enc   []byte
+	dec   []byte
+}
+
+func NewCompressedRing(capacity int, codec Codec) *CompressedRing {
+	return &CompressedRing{
+		rb:    NewRingBuffer(capacity, WithChunks()),
+		codec: codec,
+	}
+}
+
+func (cr *CompressedRing) Capacity() int {
+	return cr.rb.Capacity()
+}
+
+// Size is the number of encoded bytes held.
+func (cr *CompressedRing) Size() int {
+	return cr.rb.Size()
+}
+
+func (cr *CompressedRing) Chunks() int {
+	return cr.rb.Chunks()
+}
+
+// Write encodes p and stores it as one chunk. It fails without storing
+// anything if the encoded chunk does not fit.
+func (cr *CompressedRing) Write(p []byte) (int, error) {
+	enc, err := cr.codec.Encode(cr.enc[:0], p)
+	cr.enc = enc
+	if err != nil {
+		return 0, err
+	}
+	if _, err := cr.rb.Write(enc); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// ReadChunk decodes the oldest chunk into dst. It returns io.ErrShortBuffer,
+// consuming nothing, if dst is too small for the decoded data.
+func (cr *CompressedRing) ReadChunk(dst []byte) (int, error) {
+	n, ok := cr.rb.NextChunkSize()
+	if !ok {
+		return 0, ErrEmpty
+	}
+	if cap(cr.enc) < n {
+		cr.enc = make([]byte, n)
+	}
+	enc := cr.enc[:n]
+	cr.rb.copyOut(enc, 0, n)
+
+	dec, err := cr.codec.Decode(cr.dec[:0], enc)
+	cr.dec = dec
+	if err != nil {
+		return 0, err
+	}
+	if len(dst) < len(dec) {
+		return 0, io.ErrShortBuffer
+	}
+	cr.rb.DropOldest(n)
+
+	return copy(dst, dec), nil
+}
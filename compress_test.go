@@ -0,0 +1,52 @@
+package ringbuffer
+
+import (
+	"bytes"
+	"compress/flate"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CompressedRing(t *testing.T) {
+
+	cr := NewCompressedRing(256, NewFlateCodec(flate.BestSpeed))
+	assert.Equal(t, 256, cr.Capacity())
+
+	logs := [][]byte{
+		bytes.Repeat([]byte("level=info msg=ok "), 50),
+		bytes.Repeat([]byte("level=warn msg=slow "), 40),
+		{},
+	}
+	for _, p := range logs {
+		n, err := cr.Write(p)
+		assert.Nil(t, err)
+		assert.Equal(t, len(p), n)
+	}
+	assert.Equal(t, 3, cr.Chunks())
+	assert.Less(t, cr.Size(), len(logs[0]))
+
+	noise := make([]byte, 1000)
+	rand.New(rand.NewSource(1)).Read(noise)
+	_, err := cr.Write(noise)
+	assert.NotNil(t, err)
+	assert.Equal(t, 3, cr.Chunks())
+
+	out := make([]byte, 2000)
+	_, err = cr.ReadChunk(out[:10])
+	assert.ErrorIs(t, err, io.ErrShortBuffer)
+
+	for _, p := range logs[:2] {
+		n, err := cr.ReadChunk(out)
+		assert.Nil(t, err)
+		assert.Equal(t, p, out[:n])
+	}
+	n, err := cr.ReadChunk(out)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, n)
+
+	_, err = cr.ReadChunk(out)
+	assert.ErrorIs(t, err, ErrEmpty)
+}
@@ -0,0 +1,82 @@
+package ringbuffer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Credits implements credit-based flow control between pipeline stages
+// that do not share a buffer: the producer acquires byte allowances
+// before sending, and the consumer grants them back once it has made
+// room downstream. It is safe for concurrent use.
+type Credits struct {
+	mu      sync.Mutex
+	avail   int
+	limit   int
+	changed chan struct{}
+}
+
+// NewCredits starts with limit credits available; limit is also the
+// most that can ever be outstanding.
+func NewCredits(limit int) *Credits {
+	return &Credits{avail: limit, limit: limit, changed: make(chan struct{})}
+}
+
+func (c *Credits) Available() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.avail
+}
+
+// TryAcquire takes n credits if they are available right now.
+func (c *Credits) TryAcquire(n int) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if n > c.avail {
+		return false
+	}
+	c.avail -= n
+
+	return true
+}
+
+// Acquire waits until n credits are available and takes them. It fails
+// immediately if n exceeds the limit and with ctx.Err() if ctx ends.
+func (c *Credits) Acquire(ctx context.Context, n int) error {
+	if n > c.limit {
+		return errors.New(fmt.Sprintf("credits requested exceed limit. %d > %d", n, c.limit))
+	}
+	c.mu.Lock()
+	for n > c.avail {
+		changed := c.changed
+		c.mu.Unlock()
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		c.mu.Lock()
+	}
+	c.avail -= n
+	c.mu.Unlock()
+
+	return nil
+}
+
+// Grant returns n credits to the producer. Credits above the limit are
+// ignored.
+func (c *Credits) Grant(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.avail += n
+	if c.avail > c.limit {
+		c.avail = c.limit
+	}
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
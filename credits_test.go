@@ -0,0 +1,75 @@
+package ringbuffer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Credits(t *testing.T) {
+
+	c := NewCredits(8)
+	assert.True(t, c.TryAcquire(5))
+	assert.False(t, c.TryAcquire(4))
+	assert.Equal(t, 3, c.Available())
+
+	c.Grant(100)
+	assert.Equal(t, 8, c.Available())
+	assert.Error(t, c.Acquire(context.Background(), 9))
+}
+
+func Test_CreditsAcquireWaits(t *testing.T) {
+
+	c := NewCredits(4)
+	c.TryAcquire(4)
+
+	done := make(chan error)
+	go func() {
+		done <- c.Acquire(context.Background(), 3)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	c.Grant(2)
+	select {
+	case <-done:
+		t.Fatal("acquired with too few credits")
+	case <-time.After(10 * time.Millisecond):
+	}
+	c.Grant(2)
+	assert.Nil(t, <-done)
+	assert.Equal(t, 1, c.Available())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, c.Acquire(ctx, 2))
+}
+
+func Test_CreditsPipeline(t *testing.T) {
+
+	// The producer may only have 16 bytes in flight in the stage ring.
+	c := NewCredits(16)
+	stage := NewBlockingRingBuffer(16, nil)
+	const total = 10000
+
+	go func() {
+		chunk := make([]byte, 7)
+		for sent := 0; sent < total; sent += len(chunk) {
+			c.Acquire(context.Background(), len(chunk))
+			stage.Write(chunk)
+		}
+		stage.Close()
+	}()
+
+	got := 0
+	buf := make([]byte, 5)
+	for {
+		n, err := stage.Read(buf)
+		if err != nil {
+			break
+		}
+		got += n
+		c.Grant(n)
+	}
+	assert.Equal(t, (total+6)/7*7, got)
+}
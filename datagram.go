@@ -0,0 +1,88 @@
+package ringbuffer
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DatagramPolicy decides what WriteDatagram does when a datagram does not
+// fit in the remaining space.
+type DatagramPolicy int
+
+const (
+	// DatagramDropNewest discards the incoming datagram, like a full
+	// socket receive queue.
+	DatagramDropNewest DatagramPolicy = iota
+	// DatagramDropOldest evicts whole buffered datagrams until it fits.
+	DatagramDropOldest
+	// DatagramError rejects the datagram with an error.
+	DatagramError
+)
+
+// DatagramRing is a bounded datagram queue: every WriteDatagram is
+// delivered intact by one ReadDatagram.
+type DatagramRing struct {
+	rb      *RingBuffer
+	policy  DatagramPolicy
+	dropped uint64
+}
+
+func NewDatagramRing(capacity int, policy DatagramPolicy) *DatagramRing {
+	return &DatagramRing{
+		rb:     NewRingBuffer(capacity, WithChunks()),
+		policy: policy,
+	}
+}
+
+func (dr *DatagramRing) Capacity() int {
+	return dr.rb.Capacity()
+}
+
+func (dr *DatagramRing) Size() int {
+	return dr.rb.Size()
+}
+
+// Datagrams reports the number of queued datagrams.
+func (dr *DatagramRing) Datagrams() int {
+	return dr.rb.Chunks()
+}
+
+// Dropped returns the number of datagrams discarded by the policy,
+// incoming or evicted.
+func (dr *DatagramRing) Dropped() uint64 {
+	return dr.dropped
+}
+
+// WriteDatagram queues p, applying the policy when it does not fit. A
+// datagram larger than the capacity never fits and is dropped or, under
+// DatagramError, rejected. Empty datagrams are ignored.
+func (dr *DatagramRing) WriteDatagram(p []byte) error {
+	if len(p) == 0 {
+		return nil
+	}
+	if free := dr.rb.AvailableWriteSize(); len(p) > free {
+		switch {
+		case dr.policy == DatagramError:
+			return errors.New(fmt.Sprintf("datagram exceeds free space. %d > %d", len(p), free))
+		case dr.policy == DatagramDropOldest && len(p) <= dr.rb.Capacity():
+			for dr.rb.AvailableWriteSize() < len(p) {
+				n, _ := dr.rb.NextChunkSize()
+				dr.rb.DropOldest(n)
+				dr.dropped++
+			}
+		default:
+			dr.dropped++
+			return nil
+		}
+	}
+	_, err := dr.rb.Write(p)
+
+	return err
+}
+
+// ReadDatagram reads the oldest datagram into dst. It returns ErrEmpty
+// when the queue is empty and io.ErrShortBuffer, consuming nothing, when
+// dst is too small.
+func (dr *DatagramRing) ReadDatagram(dst []byte) (int, error) {
+	return dr.rb.ReadChunk(dst)
+}
@@ -0,0 +1,59 @@
+package ringbuffer
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DatagramRing(t *testing.T) {
+
+	dr := NewDatagramRing(8, DatagramDropNewest)
+	assert.Nil(t, dr.WriteDatagram([]byte{1, 2, 3}))
+	assert.Nil(t, dr.WriteDatagram([]byte{4, 5}))
+	assert.Nil(t, dr.WriteDatagram([]byte{6, 7, 8, 9}))
+	assert.Equal(t, 2, dr.Datagrams())
+	assert.Equal(t, uint64(1), dr.Dropped())
+
+	out := make([]byte, 8)
+	_, err := dr.ReadDatagram(out[:2])
+	assert.Equal(t, io.ErrShortBuffer, err)
+	n, err := dr.ReadDatagram(out)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, out[:n])
+	n, _ = dr.ReadDatagram(out)
+	assert.Equal(t, []byte{4, 5}, out[:n])
+	_, err = dr.ReadDatagram(out)
+	assert.Equal(t, ErrEmpty, err)
+}
+
+func Test_DatagramDropOldest(t *testing.T) {
+
+	dr := NewDatagramRing(8, DatagramDropOldest)
+	dr.WriteDatagram([]byte{1, 2, 3})
+	dr.WriteDatagram([]byte{4, 5})
+	dr.WriteDatagram([]byte{6, 7})
+	dr.WriteDatagram([]byte{8, 9, 10, 11})
+	assert.Equal(t, uint64(1), dr.Dropped())
+
+	out := make([]byte, 8)
+	n, _ := dr.ReadDatagram(out)
+	assert.Equal(t, []byte{4, 5}, out[:n])
+	n, _ = dr.ReadDatagram(out)
+	assert.Equal(t, []byte{6, 7}, out[:n])
+	n, _ = dr.ReadDatagram(out)
+	assert.Equal(t, []byte{8, 9, 10, 11}, out[:n])
+
+	dr.WriteDatagram(make([]byte, 9))
+	assert.Equal(t, uint64(2), dr.Dropped())
+	assert.Equal(t, 0, dr.Size())
+}
+
+func Test_DatagramError(t *testing.T) {
+
+	dr := NewDatagramRing(4, DatagramError)
+	assert.Nil(t, dr.WriteDatagram([]byte{1, 2, 3}))
+	assert.Error(t, dr.WriteDatagram([]byte{4, 5}))
+	assert.Equal(t, uint64(0), dr.Dropped())
+}
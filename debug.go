@@ -0,0 +1,48 @@
+package ringbuffer
+
+import (
+	"encoding/json"
+)
+
+// BufferState is a serializable snapshot of the buffer internals for bug
+// reports and support tooling. It never includes buffered data.
+type BufferState struct {
+	Capacity  int    `json:"capacity"`
+	Size      int    `json:"size"`
+	ReadPos   int    `json:"readPos"`
+	WritePos  int    `json:"writePos"`
+	Unread    int    `json:"unread"`
+	Written   uint64 `json:"written"`
+	Consumed  uint64 `json:"consumed"`
+	Lost      uint64 `json:"lost"`
+	Paused    bool   `json:"paused"`
+	Strict    bool   `json:"strict"`
+	Overwrite bool   `json:"overwrite"`
+	Chunks    int    `json:"chunks"`
+	Waiters   int    `json:"waiters"`
+	Stats     Stats  `json:"stats"`
+}
+
+func (rb *RingBuffer) DebugState() BufferState {
+	return BufferState{
+		Capacity:  rb.capacity,
+		Size:      rb.size,
+		ReadPos:   rb.readPos,
+		WritePos:  rb.writePos,
+		Unread:    rb.unread,
+		Written:   rb.written,
+		Consumed:  rb.consumed,
+		Lost:      rb.lost,
+		Paused:    rb.paused,
+		Strict:    rb.strict,
+		Overwrite: rb.overwrite,
+		Chunks:    rb.Chunks(),
+		Waiters:   len(rb.waiters),
+		Stats:     rb.Stats(),
+	}
+}
+
+// MarshalJSON encodes the DebugState of the buffer.
+func (rb *RingBuffer) MarshalJSON() ([]byte, error) {
+	return json.Marshal(rb.DebugState())
+}
@@ -0,0 +1,34 @@
+package ringbuffer
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DebugState(t *testing.T) {
+
+	rb := NewRingBuffer(5, WithChunks())
+	rb.Write([]byte{1, 2, 3, 4})
+	rb.ReadExact(3, make([]byte, 3))
+	rb.Write([]byte{5, 6})
+
+	st := rb.DebugState()
+	assert.Equal(t, 5, st.Capacity)
+	assert.Equal(t, 3, st.Size)
+	assert.Equal(t, 3, st.ReadPos)
+	assert.Equal(t, 1, st.WritePos)
+	assert.Equal(t, 2, st.Unread)
+	assert.Equal(t, uint64(6), st.Written)
+	assert.Equal(t, uint64(3), st.Consumed)
+	assert.Equal(t, 2, st.Chunks)
+
+	data, err := json.Marshal(rb)
+	assert.Nil(t, err)
+
+	var decoded BufferState
+	assert.Nil(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, st, decoded)
+	assert.Contains(t, string(data), `"readPos":3`)
+}
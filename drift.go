@@ -0,0 +1,87 @@
+package ringbuffer
+
+import "sync/atomic"
+
+// DriftConfig configures clock drift compensation on an AudioRing. When
+// the smoothed fill level stays more than Tolerance bytes above Target,
+// a read drops one frame; when it stays below, a read duplicates one.
+// At most one frame is adjusted every Interval frames read.
+type DriftConfig struct {
+	Target    int
+	Tolerance int
+	Interval  int
+}
+
+// DriftStats counts the frames dropped and duplicated by drift
+// compensation.
+type DriftStats struct {
+	Dropped    uint64
+	Duplicated uint64
+}
+
+type driftController struct {
+	dropCount uint64
+	dupCount  uint64
+	cfg       DriftConfig
+	// level is the smoothed fill level in bytes, scaled by 16.
+	level int
+	init  bool
+	since int
+}
+
+// SetDriftCompensation enables drift compensation on the consumer side.
+// It must be called before the ring is in use.
+func (ar *AudioRing) SetDriftCompensation(cfg DriftConfig) {
+	if cfg.Interval < 1 {
+		cfg.Interval = 1
+	}
+	ar.drift = &driftController{cfg: cfg}
+}
+
+// DriftStats may be called from any goroutine.
+func (ar *AudioRing) DriftStats() DriftStats {
+	if ar.drift == nil {
+		return DriftStats{}
+	}
+
+	return DriftStats{
+		Dropped:    atomic.LoadUint64(&ar.drift.dropCount),
+		Duplicated: atomic.LoadUint64(&ar.drift.dupCount),
+	}
+}
+
+// observe folds the current fill level into the moving average and
+// returns +1 to drop a frame, -1 to duplicate one, or 0.
+func (d *driftController) observe(avail int) int {
+	if !d.init {
+		d.level = avail * 16
+		d.init = true
+	} else {
+		d.level += avail - d.level/16
+	}
+	if d.since < d.cfg.Interval {
+		return 0
+	}
+	switch level := d.level / 16; {
+	case level > d.cfg.Target+d.cfg.Tolerance:
+		return 1
+	case level < d.cfg.Target-d.cfg.Tolerance:
+		return -1
+	}
+
+	return 0
+}
+
+func (d *driftController) advance(frames int) {
+	d.since += frames
+}
+
+func (d *driftController) dropped() {
+	atomic.AddUint64(&d.dropCount, 1)
+	d.since = 0
+}
+
+func (d *driftController) duplicated() {
+	atomic.AddUint64(&d.dupCount, 1)
+	d.since = 0
+}
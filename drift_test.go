@@ -0,0 +1,62 @@
+package ringbuffer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var mono8 = PCMFormat{SampleRate: 8000, Channels: 1, BitsPerSample: 8}
+
+func Test_DriftDropsWhenFilling(t *testing.T) {
+
+	ar, _ := NewAudioRing(64, mono8)
+	ar.SetDriftCompensation(DriftConfig{Target: 16, Tolerance: 4, Interval: 8})
+	ar.Write(make([]byte, 32))
+
+	in := make([]byte, 4)
+	out := make([]byte, 4)
+	for i := 0; i < 500; i++ {
+		ar.Write(in)
+		ar.FillOutput(out)
+	}
+	stats := ar.DriftStats()
+	assert.True(t, stats.Dropped >= 12)
+	assert.Equal(t, uint64(0), stats.Duplicated)
+	assert.True(t, ar.Size() <= 20+4)
+}
+
+func Test_DriftDuplicatesWhenDraining(t *testing.T) {
+
+	ar, _ := NewAudioRing(64, mono8)
+	ar.SetDriftCompensation(DriftConfig{Target: 16, Tolerance: 4, Interval: 64})
+	ar.Write(make([]byte, 4))
+
+	var next byte
+	in := make([]byte, 4)
+	out := make([]byte, 4)
+	var prev byte
+	for i := 0; i < 400; i++ {
+		for j := range in {
+			next++
+			in[j] = next
+		}
+		ar.Write(in)
+		n := ar.Read(out)
+		for j := 0; j < n; j++ {
+			// Values never go backwards; a repeat is a duplicated frame.
+			assert.True(t, out[j] == prev || out[j] == prev+1 || i == 0)
+			prev = out[j]
+		}
+	}
+	stats := ar.DriftStats()
+	assert.True(t, stats.Duplicated > 0)
+	assert.Equal(t, uint64(0), stats.Dropped)
+	assert.True(t, ar.Size() >= 8)
+}
+
+func Test_DriftDisabled(t *testing.T) {
+
+	ar, _ := NewAudioRing(8, mono8)
+	assert.Equal(t, DriftStats{}, ar.DriftStats())
+}
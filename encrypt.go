@@ -0,0 +1,131 @@
+package ringbuffer
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+)
+
+// WithEncryption keeps the buffered bytes encrypted with block in CTR
+// mode so sensitive data does not sit in memory as plaintext. Copying
+// reads, peeks, HasPrefix and Equal see plaintext; zero-copy views
+// (ReadIovec, the %+v preview) expose ciphertext. Keystream is never
+// reused: every buffer, including each Clone, draws its own random
+// counter base, and Reset and TruncateNewest, which rewind the written
+// sequence, draw a new one and re-encrypt what is still held. Pass it
+// after any other write transforms and before any other read transforms.
+func WithEncryption(block cipher.Block) Option {
+	return func(rb *RingBuffer) {
+		c := &ctrCipher{
+			block: block,
+			iv:    make([]byte, block.BlockSize()),
+			ctr:   make([]byte, block.BlockSize()),
+			ks:    make([]byte, block.BlockSize()),
+		}
+		c.rekey()
+		rb.enc = c
+		rb.writeXform = append(rb.writeXform, c)
+		rb.readXform = append([]Transformer{c}, rb.readXform...)
+	}
+}
+
+// ctrCipher is a seekable CTR keystream: the counter for stream offset
+// seq is iv + seq/blockSize.
+type ctrCipher struct {
+	block cipher.Block
+	iv    []byte
+	ctr   []byte
+	ks    []byte
+}
+
+func (c *ctrCipher) rekey() {
+	if _, err := rand.Read(c.iv); err != nil {
+		panic("ringbuffer: reading random iv: " + err.Error())
+	}
+}
+
+func (c *ctrCipher) Transform(p []byte, seq uint64) {
+	bs := uint64(len(c.iv))
+	c.setCounter(seq / bs)
+	skip := int(seq % bs)
+	for len(p) > 0 {
+		c.block.Encrypt(c.ks, c.ctr)
+		n := len(c.ks) - skip
+		if n > len(p) {
+			n = len(p)
+		}
+		for i := 0; i < n; i++ {
+			p[i] ^= c.ks[skip+i]
+		}
+		p = p[n:]
+		skip = 0
+		c.incCounter()
+	}
+}
+
+// setCounter sets ctr to the big-endian sum iv + blk.
+func (c *ctrCipher) setCounter(blk uint64) {
+	carry := blk
+	for i := len(c.iv) - 1; i >= 0; i-- {
+		sum := uint64(c.iv[i]) + carry&0xff
+		c.ctr[i] = byte(sum)
+		carry = carry>>8 + sum>>8
+	}
+}
+
+func (c *ctrCipher) incCounter() {
+	for i := len(c.ctr) - 1; i >= 0; i-- {
+		c.ctr[i]++
+		if c.ctr[i] != 0 {
+			return
+		}
+	}
+}
+
+// clone returns a copy with its own scratch space and the same keystream;
+// the caller rekeys it once the copied data is in place.
+func (c *ctrCipher) clone() *ctrCipher {
+	return &ctrCipher{
+		block: c.block,
+		iv:    append([]byte(nil), c.iv...),
+		ctr:   make([]byte, len(c.ctr)),
+		ks:    make([]byte, len(c.ks)),
+	}
+}
+
+// rekeyHeld draws a new counter base and re-encrypts the bytes still
+// held under the old one: the buffered bytes and the consumed ones that
+// Unread can recover.
+func (rb *RingBuffer) rekeyHeld() {
+	n := rb.unread + rb.size
+	seq := rb.consumed - uint64(rb.unread)
+	start := rb.readPos - rb.unread
+	if start < 0 {
+		start += rb.capacity
+	}
+	var s1, s2 []byte
+	if n > 0 {
+		if start+n <= rb.capacity {
+			s1 = rb.buf[start : start+n]
+		} else {
+			s1, s2 = rb.buf[start:], rb.buf[:start+n-rb.capacity]
+		}
+	}
+	rb.enc.Transform(s1, seq)
+	rb.enc.Transform(s2, seq+uint64(len(s1)))
+	rb.enc.rekey()
+	rb.enc.Transform(s1, seq)
+	rb.enc.Transform(s2, seq+uint64(len(s1)))
+}
+
+// replaceTransform returns a copy of ts with old replaced by new.
+func replaceTransform(ts []Transformer, old, new Transformer) []Transformer {
+	out := make([]Transformer, len(ts))
+	for i, t := range ts {
+		if t == old {
+			t = new
+		}
+		out[i] = t
+	}
+
+	return out
+}
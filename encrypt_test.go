@@ -0,0 +1,124 @@
+package ringbuffer
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestEncrypted(t *testing.T, capacity int) *RingBuffer {
+	block, err := aes.NewCipher(bytes.Repeat([]byte{0x42}, 16))
+	assert.Nil(t, err)
+
+	return NewRingBuffer(capacity, WithEncryption(block))
+}
+
+func Test_EncryptionRoundTrip(t *testing.T) {
+	rb := newTestEncrypted(t, 40)
+	in := bytes.Repeat([]byte("secret audio!"), 10)
+
+	var got []byte
+	out := make([]byte, 7)
+	for off := 0; off < len(in); off += 13 {
+		_, err := rb.Write(in[off : off+13])
+		assert.Nil(t, err)
+		for rb.Size() >= 7 {
+			n, err := rb.ReadExact(7, out)
+			assert.Nil(t, err)
+			got = append(got, out[:n]...)
+		}
+	}
+	got = append(got, rb.Bytes()...)
+	assert.Equal(t, in, got)
+}
+
+func Test_EncryptionAtRest(t *testing.T) {
+	rb := newTestEncrypted(t, 32)
+	plain := []byte("0123456789abcdef0123")
+	rb.Write(plain)
+
+	assert.True(t, rb.HasPrefix(plain[:4]))
+	assert.True(t, rb.HasPrefix(plain))
+	assert.False(t, rb.HasPrefix([]byte("0124")))
+	assert.True(t, rb.Equal(plain))
+	assert.False(t, bytes.Contains(rb.buf, plain[:8]))
+	assert.Equal(t, plain, rb.Bytes())
+
+	rb.DropOldest(5)
+	pc := rb.PeekCursor()
+	out := make([]byte, 6)
+	pc.Read(out)
+	assert.Equal(t, plain[5:11], out)
+}
+
+func Test_EncryptionResetRekeys(t *testing.T) {
+	rb := newTestEncrypted(t, 16)
+	rb.Write(make([]byte, 8))
+	first := append([]byte(nil), rb.buf[:8]...)
+
+	rb.Reset()
+	rb.Write(make([]byte, 8))
+	assert.NotEqual(t, first, rb.buf[:8])
+	assert.Equal(t, make([]byte, 8), rb.Bytes())
+}
+
+func Test_EncryptionClone(t *testing.T) {
+	rb := newTestEncrypted(t, 16)
+	rb.Write([]byte("hello"))
+
+	c := rb.Clone()
+	assert.NotEqual(t, rb.enc.iv, c.enc.iv)
+	assert.NotEqual(t, rb.buf[:5], c.buf[:5])
+	assert.Equal(t, []byte("hello"), c.Bytes())
+	c.Reset()
+	assert.Equal(t, []byte("hello"), rb.Bytes())
+}
+
+func Test_EncryptionOptionPerBuffer(t *testing.T) {
+	block, _ := aes.NewCipher(bytes.Repeat([]byte{0x42}, 16))
+	opt := WithEncryption(block)
+	a := NewRingBuffer(16, opt)
+	b := NewRingBuffer(16, opt)
+	assert.NotEqual(t, a.enc.iv, b.enc.iv)
+
+	a.Write(make([]byte, 8))
+	b.Write(make([]byte, 8))
+	assert.NotEqual(t, a.buf[:8], b.buf[:8])
+}
+
+func Test_EncryptionTruncateRekeys(t *testing.T) {
+	rb := newTestEncrypted(t, 16)
+	rb.Write([]byte("abcdef\x00\x00"))
+	rb.ReadExact(2, make([]byte, 2))
+	// zero plaintext stores the raw keystream
+	atRest := append([]byte(nil), rb.buf[6:8]...)
+
+	assert.Nil(t, rb.TruncateNewest(2))
+	rb.Write(make([]byte, 2))
+	// the rewritten sequence gets fresh keystream
+	assert.NotEqual(t, atRest, rb.buf[6:8])
+	assert.Equal(t, []byte("cdef\x00\x00"), rb.Bytes())
+
+	// bytes consumed before the rekey are still recoverable
+	assert.Nil(t, rb.Unread(2))
+	assert.Equal(t, []byte("abcdef\x00\x00"), rb.Bytes())
+}
+
+func Test_EncryptionMatchesCTR(t *testing.T) {
+	block, _ := aes.NewCipher(bytes.Repeat([]byte{0x42}, 16))
+	c := &ctrCipher{block: block, iv: make([]byte, 16), ctr: make([]byte, 16), ks: make([]byte, 16)}
+	for i := range c.iv {
+		c.iv[i] = 0xff
+	}
+
+	want := make([]byte, 100)
+	cipher.NewCTR(block, c.iv).XORKeyStream(want, want)
+
+	got := make([]byte, 100)
+	c.Transform(got[:37], 0)
+	c.Transform(got[37:], 37)
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,70 @@
+package ringbuffer
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+type fadeState struct {
+	frames int
+	// pos is how far the current fade-in has progressed; it equals
+	// frames when no fade-in is running.
+	pos int
+}
+
+// SetFade makes FillOutput ramp the audio down over the last frames
+// before an underrun and back up over the first frames after it, to
+// avoid clicks around injected silence. Zero disables fading. It must be
+// called before the ring is in use.
+func (ar *AudioRing) SetFade(frames int) {
+	ar.fade = fadeState{frames: frames, pos: frames}
+}
+
+func (ar *AudioRing) applyFade(data []byte, underrun bool) {
+	n := len(data) / ar.frame
+	f := &ar.fade
+	for i := 0; i < n && f.pos < f.frames; i++ {
+		ar.scaleFrame(data[i*ar.frame:(i+1)*ar.frame], f.pos, f.frames)
+		f.pos++
+	}
+	if !underrun {
+		return
+	}
+	k := f.frames
+	if k > n {
+		k = n
+	}
+	for j := 0; j < k; j++ {
+		i := n - k + j
+		ar.scaleFrame(data[i*ar.frame:(i+1)*ar.frame], k-1-j, k)
+	}
+	f.pos = 0
+}
+
+// scaleFrame multiplies every sample of frame by num/den.
+func (ar *AudioRing) scaleFrame(frame []byte, num, den int) {
+	size := ar.format.BitsPerSample / 8
+	for s := 0; s+size <= len(frame); s += size {
+		b := frame[s : s+size]
+		switch {
+		case ar.format.Float && size == 4:
+			v := math.Float32frombits(binary.LittleEndian.Uint32(b))
+			binary.LittleEndian.PutUint32(b, math.Float32bits(v*float32(num)/float32(den)))
+		case ar.format.Float && size == 8:
+			v := math.Float64frombits(binary.LittleEndian.Uint64(b))
+			binary.LittleEndian.PutUint64(b, math.Float64bits(v*float64(num)/float64(den)))
+		case size == 1:
+			b[0] = byte((int(b[0])-0x80)*num/den + 0x80)
+		case size == 2:
+			v := int64(int16(binary.LittleEndian.Uint16(b)))
+			binary.LittleEndian.PutUint16(b, uint16(v*int64(num)/int64(den)))
+		case size == 3:
+			v := int64(int32(uint32(b[0])<<8|uint32(b[1])<<16|uint32(b[2])<<24) >> 8)
+			v = v * int64(num) / int64(den)
+			b[0], b[1], b[2] = byte(v), byte(v>>8), byte(v>>16)
+		case size == 4:
+			v := int64(int32(binary.LittleEndian.Uint32(b)))
+			binary.LittleEndian.PutUint32(b, uint32(v*int64(num)/int64(den)))
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package ringbuffer
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FadeOnUnderrun(t *testing.T) {
+
+	ar, _ := NewAudioRing(16, PCMFormat{SampleRate: 8000, Channels: 1, BitsPerSample: 16})
+	ar.SetFade(2)
+
+	le16 := func(vs ...int16) []byte {
+		b := make([]byte, 2*len(vs))
+		for i, v := range vs {
+			binary.LittleEndian.PutUint16(b[2*i:], uint16(v))
+		}
+		return b
+	}
+
+	// Steady playback is untouched.
+	ar.Write(le16(1000, 1000))
+	out := make([]byte, 4)
+	ar.FillOutput(out)
+	assert.Equal(t, le16(1000, 1000), out)
+
+	// Underrun: the last real frames ramp down to silence.
+	ar.Write(le16(1000, 1000, 1000))
+	out = make([]byte, 10)
+	filled, underrun := ar.FillOutput(out)
+	assert.Equal(t, 6, filled)
+	assert.True(t, underrun)
+	assert.Equal(t, le16(1000, 500, 0, 0, 0), out)
+
+	// Recovery: the first frames ramp back up.
+	ar.Write(le16(-1000, -1000, -1000))
+	out = make([]byte, 6)
+	ar.FillOutput(out)
+	assert.Equal(t, le16(0, -500, -1000), out)
+}
+
+func Test_FadeFormats(t *testing.T) {
+
+	ar, _ := NewAudioRing(8, PCMFormat{SampleRate: 8000, Channels: 1, BitsPerSample: 8})
+	b := []byte{0x80 + 100}
+	ar.scaleFrame(b, 1, 2)
+	assert.Equal(t, []byte{0x80 + 50}, b)
+
+	ar, _ = NewAudioRing(6, PCMFormat{SampleRate: 8000, Channels: 1, BitsPerSample: 24})
+	b = []byte{0x00, 0x00, 0xf0}
+	ar.scaleFrame(b, 1, 2)
+	assert.Equal(t, []byte{0x00, 0x00, 0xf8}, b)
+
+	ar, _ = NewAudioRing(8, PCMFormat{SampleRate: 8000, Channels: 1, BitsPerSample: 32, Float: true})
+	b = make([]byte, 4)
+	binary.LittleEndian.PutUint32(b, math.Float32bits(0.5))
+	ar.scaleFrame(b, 1, 4)
+	assert.Equal(t, float32(0.125), math.Float32frombits(binary.LittleEndian.Uint32(b)))
+}
@@ -0,0 +1,120 @@
+// Package fakeclock provides a manually driven implementation of
+// ringbuffer.Clock for deterministic tests of time-based behavior.
+package fakeclock
+
+import (
+	"sync"
+	"time"
+)
+
+type Clock struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []waiter
+}
+
+type waiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func New(now time.Time) *Clock {
+	c := &Clock{now: now}
+	c.cond = sync.NewCond(&c.mu)
+
+	return c
+}
+
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// After returns a channel that receives the fake time once Advance or Set
+// moves the clock to or past now+d.
+func (c *Clock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, waiter{at: c.now.Add(d), ch: ch})
+	c.cond.Broadcast()
+
+	return ch
+}
+
+// Stop cancels the After timer that returned ch, so it no longer counts
+// towards Waiters and BlockUntil. It reports whether the timer was still
+// pending. Callers that stop waiting on a timer for another reason
+// should stop it.
+func (c *Clock) Stop(ch <-chan time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, w := range c.waiters {
+		if w.ch == ch {
+			copy(c.waiters[i:], c.waiters[i+1:])
+			c.waiters[len(c.waiters)-1] = waiter{}
+			c.waiters = c.waiters[:len(c.waiters)-1]
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.set(c.now.Add(d))
+}
+
+func (c *Clock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.set(t)
+}
+
+// Waiters reports the number of pending After timers.
+func (c *Clock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.waiters)
+}
+
+// BlockUntil waits until at least n After timers are pending, i.e. until
+// the code under test has reached its timed wait.
+func (c *Clock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for len(c.waiters) < n {
+		c.cond.Wait()
+	}
+}
+
+func (c *Clock) set(t time.Time) {
+	c.now = t
+	pending := c.waiters[:0]
+	for _, w := range c.waiters {
+		if !w.at.After(c.now) {
+			w.ch <- c.now
+			continue
+		}
+		pending = append(pending, w)
+	}
+	for i := len(pending); i < len(c.waiters); i++ {
+		c.waiters[i] = waiter{}
+	}
+	c.waiters = pending
+}
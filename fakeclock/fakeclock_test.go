@@ -0,0 +1,73 @@
+package fakeclock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func fired(ch <-chan time.Time) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func Test_After(t *testing.T) {
+
+	start := time.Unix(1000, 0)
+	c := New(start)
+	assert.Equal(t, start, c.Now())
+
+	short := c.After(time.Second)
+	long := c.After(time.Minute)
+	assert.True(t, fired(c.After(0)))
+	assert.Equal(t, 2, c.Waiters())
+
+	c.Advance(999 * time.Millisecond)
+	assert.False(t, fired(short))
+
+	c.Advance(time.Millisecond)
+	assert.True(t, fired(short))
+	assert.False(t, fired(long))
+	assert.Equal(t, 1, c.Waiters())
+
+	c.Set(start.Add(time.Hour))
+	assert.True(t, fired(long))
+	assert.Equal(t, 0, c.Waiters())
+	assert.Equal(t, start.Add(time.Hour), c.Now())
+}
+
+func Test_BlockUntil(t *testing.T) {
+
+	c := New(time.Unix(0, 0))
+
+	done := make(chan struct{})
+	go func() {
+		<-c.After(time.Second)
+		close(done)
+	}()
+
+	c.BlockUntil(1)
+	c.Advance(time.Second)
+	<-done
+}
+
+func Test_Stop(t *testing.T) {
+
+	c := New(time.Unix(0, 0))
+	a := c.After(time.Second)
+	b := c.After(2 * time.Second)
+
+	assert.True(t, c.Stop(a))
+	assert.False(t, c.Stop(a))
+	assert.Equal(t, 1, c.Waiters())
+
+	c.Advance(2 * time.Second)
+	assert.False(t, fired(a))
+	assert.True(t, fired(b))
+	assert.False(t, c.Stop(b))
+}
@@ -0,0 +1,69 @@
+package ringbuffer
+
+import (
+	"fmt"
+	"io"
+)
+
+// FanoutPolicy decides what Fanout does with a target that cannot take
+// the whole write. Targets in overwrite mode always have room: older
+// data gives way as in a direct write.
+type FanoutPolicy int
+
+const (
+	// FanoutDrop skips full targets; the others still receive the data.
+	// A target whose write fails is skipped too, and the first such
+	// error is returned once every target has been tried.
+	FanoutDrop FanoutPolicy = iota
+	// FanoutError fails the write without touching any target if one of
+	// them lacks space. A target write that fails anyway stops the
+	// fanout; the error comes with the bytes every target holds.
+	FanoutError
+)
+
+type fanoutWriter struct {
+	policy FanoutPolicy
+	rbs    []*RingBuffer
+}
+
+// Fanout returns a writer that copies every write into all rbs. There is
+// no blocking policy: RingBuffer is not synchronized, so waiting for a
+// consumer to make room is left to the caller.
+func Fanout(policy FanoutPolicy, rbs ...*RingBuffer) io.Writer {
+	return &fanoutWriter{
+		policy: policy,
+		rbs:    append([]*RingBuffer(nil), rbs...),
+	}
+}
+
+func (fw *fanoutWriter) Write(p []byte) (int, error) {
+	if fw.policy == FanoutError {
+		for i, rb := range fw.rbs {
+			if !fanoutFits(rb, p) {
+				return 0, fmt.Errorf("fanout target %d: %d bytes free, need %d", i, rb.AvailableWriteSize(), len(p))
+			}
+		}
+	}
+	var firstErr error
+	for i, rb := range fw.rbs {
+		if !fanoutFits(rb, p) {
+			continue
+		}
+		n, err := rb.Write(p)
+		if err == nil {
+			continue
+		}
+		if firstErr == nil {
+			firstErr = fmt.Errorf("fanout target %d: %w", i, err)
+		}
+		if fw.policy == FanoutError {
+			return n, firstErr
+		}
+	}
+
+	return len(p), firstErr
+}
+
+func fanoutFits(rb *RingBuffer, p []byte) bool {
+	return rb.overwrite || rb.AvailableWriteSize() >= len(p)
+}
@@ -0,0 +1,81 @@
+package ringbuffer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FanoutDrop(t *testing.T) {
+
+	a := NewRingBuffer(5)
+	b := NewRingBuffer(3)
+
+	w := Fanout(FanoutDrop, a, b)
+	n, err := w.Write([]byte{1, 2})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, n)
+
+	n, err = w.Write([]byte{3, 4})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, n)
+
+	assert.Equal(t, []byte{1, 2, 3, 4}, a.Bytes())
+	assert.Equal(t, []byte{1, 2}, b.Bytes())
+}
+
+func Test_FanoutError(t *testing.T) {
+
+	a := NewRingBuffer(5)
+	b := NewRingBuffer(3)
+
+	w := Fanout(FanoutError, a, b)
+	_, err := w.Write([]byte{1, 2})
+	assert.Nil(t, err)
+
+	n, err := w.Write([]byte{3, 4})
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, n)
+
+	assert.Equal(t, []byte{1, 2}, a.Bytes())
+	assert.Equal(t, []byte{1, 2}, b.Bytes())
+}
+
+func Test_FanoutOverwrite(t *testing.T) {
+
+	a := NewRingBuffer(5)
+	b := NewRingBuffer(3, WithOverwrite())
+
+	for _, policy := range []FanoutPolicy{FanoutDrop, FanoutError} {
+		a.Reset()
+		b.Reset()
+		w := Fanout(policy, a, b)
+		w.Write([]byte{1, 2})
+		n, err := w.Write([]byte{3, 4})
+		assert.Nil(t, err)
+		assert.Equal(t, 2, n)
+
+		assert.Equal(t, []byte{1, 2, 3, 4}, a.Bytes())
+		assert.Equal(t, []byte{2, 3, 4}, b.Bytes())
+	}
+}
+
+func Test_FanoutTargetError(t *testing.T) {
+
+	a := NewRingBuffer(8)
+	b := NewRingBuffer(8, WithPeriod(4))
+	c := NewRingBuffer(8)
+
+	n, err := Fanout(FanoutDrop, a, b, c).Write([]byte{1, 2})
+	assert.ErrorContains(t, err, "fanout target 1")
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []byte{1, 2}, a.Bytes())
+	assert.Equal(t, 0, b.Size())
+	assert.Equal(t, []byte{1, 2}, c.Bytes())
+
+	n, err = Fanout(FanoutError, a, b, c).Write([]byte{3, 4})
+	assert.ErrorContains(t, err, "fanout target 1")
+	assert.Equal(t, 0, n)
+	assert.Equal(t, []byte{1, 2, 3, 4}, a.Bytes())
+	assert.Equal(t, []byte{1, 2}, c.Bytes())
+}
@@ -0,0 +1,108 @@
+//go:build ringbuffer_faults
+
+package ringbuffer
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrInjectedFault is the default error returned by a FaultFail step.
+var ErrInjectedFault = errors.New("injected fault")
+
+type faultOp int
+
+const (
+	faultRead faultOp = iota
+	faultWrite
+)
+
+// FaultTarget selects which operation a Fault applies to.
+type FaultTarget int
+
+const (
+	FaultOnRead FaultTarget = iota
+	FaultOnWrite
+)
+
+type FaultKind int
+
+const (
+	// FaultFail makes the operation fail with Err (ErrInjectedFault if nil).
+	FaultFail FaultKind = iota
+	// FaultShort limits the operation to at most N bytes.
+	FaultShort
+	// FaultDelay sleeps for Delay before the operation proceeds.
+	FaultDelay
+)
+
+// Fault is one step of a fault script. Skip matching operations pass
+// through untouched before the step fires; each step fires once.
+type Fault struct {
+	On    FaultTarget
+	Kind  FaultKind
+	Skip  int
+	N     int
+	Delay time.Duration
+	Err   error
+}
+
+var (
+	faultsMu sync.Mutex
+	faults   = map[*RingBuffer][]Fault{}
+)
+
+// InjectFaults replaces the fault script of rb. It is only available
+// when building with the ringbuffer_faults tag, so production builds
+// carry no hook. Calling it with no steps removes the script.
+func (rb *RingBuffer) InjectFaults(script ...Fault) {
+	faultsMu.Lock()
+	defer faultsMu.Unlock()
+
+	if len(script) == 0 {
+		delete(faults, rb)
+		return
+	}
+	faults[rb] = append([]Fault(nil), script...)
+}
+
+func (rb *RingBuffer) injectFault(op faultOp, n int) (int, error) {
+	faultsMu.Lock()
+	script := faults[rb]
+	idx := -1
+	for i := range script {
+		if int(script[i].On) == int(op) {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		faultsMu.Unlock()
+		return n, nil
+	}
+	if script[idx].Skip > 0 {
+		script[idx].Skip--
+		faultsMu.Unlock()
+		return n, nil
+	}
+	f := script[idx]
+	faults[rb] = append(script[:idx:idx], script[idx+1:]...)
+	faultsMu.Unlock()
+
+	switch f.Kind {
+	case FaultFail:
+		if f.Err != nil {
+			return 0, f.Err
+		}
+		return 0, ErrInjectedFault
+	case FaultShort:
+		if f.N < n {
+			n = f.N
+		}
+	case FaultDelay:
+		time.Sleep(f.Delay)
+	}
+
+	return n, nil
+}
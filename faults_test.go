@@ -0,0 +1,56 @@
+//go:build ringbuffer_faults
+
+package ringbuffer
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_InjectFaults(t *testing.T) {
+
+	rb := NewRingBuffer(10)
+	errDevice := errors.New("device gone")
+	rb.InjectFaults(
+		Fault{On: FaultOnWrite, Kind: FaultShort, Skip: 1, N: 2},
+		Fault{On: FaultOnRead, Kind: FaultFail},
+		Fault{On: FaultOnRead, Kind: FaultDelay, Delay: 10 * time.Millisecond},
+		Fault{On: FaultOnWrite, Kind: FaultFail, Err: errDevice},
+	)
+
+	nw, err := rb.Write([]byte{1, 2, 3})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, nw)
+
+	nw, err = rb.Write([]byte{4, 5, 6})
+	assert.ErrorIs(t, err, io.ErrShortWrite)
+	assert.Equal(t, 2, nw)
+
+	out := make([]byte, 5)
+	nr, err := rb.ReadExact(5, out)
+	assert.ErrorIs(t, err, ErrInjectedFault)
+	assert.Equal(t, 0, nr)
+
+	start := time.Now()
+	nr, err = rb.ReadExact(5, out)
+	assert.Nil(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 10*time.Millisecond)
+	assert.Equal(t, []byte{1, 2, 3, 4, 5}, out[:nr])
+
+	_, err = rb.Write([]byte{7})
+	assert.ErrorIs(t, err, errDevice)
+
+	nw, err = rb.Write([]byte{7})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, nw)
+
+	rb.InjectFaults(Fault{On: FaultOnRead, Kind: FaultShort, N: 0})
+	rb.InjectFaults()
+	nr, err = rb.ReadExact(1, out)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, nr)
+}
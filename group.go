@@ -0,0 +1,117 @@
+package ringbuffer
+
+import (
+	"sync"
+)
+
+// ConsumerGroup drains a ring in disjoint chunks processed concurrently
+// by Workers goroutines. Results are handed to Commit in stream order, so
+// the processing can be parallel while the output stays ordered.
+type ConsumerGroup struct {
+	Workers   int
+	ChunkSize int
+	Process   func(chunk []byte) ([]byte, error)
+	Commit    func(out []byte) error
+}
+
+// Drain consumes everything currently buffered in rb. The ring is only
+// touched from a single goroutine, so it must not be used concurrently
+// until Drain returns. Processing stops at the first error, including a
+// failed read from rb such as ErrPaused, which is returned after all
+// in-flight chunks finish. On a period-mode ring chunks are whole
+// periods.
+func (g *ConsumerGroup) Drain(rb *RingBuffer) error {
+	workers := g.Workers
+	if workers < 1 {
+		workers = 1
+	}
+	chunkSize := g.ChunkSize
+	if chunkSize < 1 {
+		chunkSize = rb.Capacity()
+	}
+
+	type job struct {
+		idx   int
+		chunk []byte
+	}
+	type result struct {
+		idx int
+		out []byte
+		err error
+	}
+	jobs := make(chan job)
+	results := make(chan result)
+	stop := make(chan struct{})
+	// readErr is written by the feeder before it closes results.
+	var readErr error
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				out, err := g.Process(j.chunk)
+				results <- result{idx: j.idx, out: out, err: err}
+			}
+		}()
+	}
+
+	go func() {
+		defer func() {
+			close(jobs)
+			wg.Wait()
+			close(results)
+		}()
+		for idx := 0; rb.Size() > 0; idx++ {
+			n := chunkSize
+			if n > rb.Size() {
+				n = rb.Size()
+			}
+			if rb.period > 0 && n >= rb.period {
+				n -= n % rb.period
+			}
+			chunk := make([]byte, n)
+			if _, err := rb.ReadExact(n, chunk); err != nil {
+				readErr = err
+				return
+			}
+			select {
+			case jobs <- job{idx: idx, chunk: chunk}:
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	var firstErr error
+	pending := make(map[int]result)
+	next := 0
+	for r := range results {
+		pending[r.idx] = r
+		for {
+			p, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			next++
+			if firstErr != nil {
+				continue
+			}
+			err := p.err
+			if err == nil && g.Commit != nil {
+				err = g.Commit(p.out)
+			}
+			if err != nil {
+				firstErr = err
+				close(stop)
+			}
+		}
+	}
+	if firstErr == nil {
+		firstErr = readErr
+	}
+
+	return firstErr
+}
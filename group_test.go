@@ -0,0 +1,111 @@
+package ringbuffer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ConsumerGroup(t *testing.T) {
+
+	rb := NewRingBuffer(1000)
+	data := make([]byte, 1000)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	rb.Write(data)
+
+	var got []byte
+	g := ConsumerGroup{
+		Workers:   4,
+		ChunkSize: 7,
+		Process: func(chunk []byte) ([]byte, error) {
+			out := make([]byte, len(chunk))
+			for i, b := range chunk {
+				out[i] = b + 1
+			}
+			return out, nil
+		},
+		Commit: func(out []byte) error {
+			got = append(got, out...)
+			return nil
+		},
+	}
+	assert.Nil(t, g.Drain(rb))
+	assert.Equal(t, 0, rb.Size())
+
+	assert.Equal(t, len(data), len(got))
+	for i := range data {
+		assert.Equal(t, data[i]+1, got[i])
+	}
+}
+
+func Test_ConsumerGroupError(t *testing.T) {
+
+	rb := NewRingBuffer(100)
+	rb.Write(make([]byte, 100))
+
+	errBad := errors.New("bad chunk")
+	commits := 0
+	g := ConsumerGroup{
+		Workers:   2,
+		ChunkSize: 10,
+		Process: func(chunk []byte) ([]byte, error) {
+			return chunk, nil
+		},
+		Commit: func(out []byte) error {
+			commits++
+			if commits == 3 {
+				return errBad
+			}
+			return nil
+		},
+	}
+	assert.ErrorIs(t, g.Drain(rb), errBad)
+	assert.Equal(t, 3, commits)
+}
+
+func Test_ConsumerGroupReadError(t *testing.T) {
+
+	rb := NewRingBuffer(100)
+	rb.Write(make([]byte, 50))
+	rb.Pause()
+
+	commits := 0
+	g := ConsumerGroup{
+		Workers:   2,
+		ChunkSize: 10,
+		Process: func(chunk []byte) ([]byte, error) {
+			return chunk, nil
+		},
+		Commit: func(out []byte) error {
+			commits++
+			return nil
+		},
+	}
+	assert.ErrorIs(t, g.Drain(rb), ErrPaused)
+	assert.Equal(t, 0, commits)
+	assert.Equal(t, 50, rb.Size())
+}
+
+func Test_ConsumerGroupPeriods(t *testing.T) {
+
+	rb := NewRingBuffer(100, WithPeriod(4))
+	rb.Write(make([]byte, 40))
+
+	var sizes []int
+	g := ConsumerGroup{
+		Workers:   1,
+		ChunkSize: 10,
+		Process: func(chunk []byte) ([]byte, error) {
+			return chunk, nil
+		},
+		Commit: func(out []byte) error {
+			sizes = append(sizes, len(out))
+			return nil
+		},
+	}
+	assert.Nil(t, g.Drain(rb))
+	assert.Equal(t, []int{8, 8, 8, 8, 8}, sizes)
+}
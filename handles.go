@@ -0,0 +1,109 @@
+package ringbuffer
+
+// Reader is the consumer half of a RingBuffer. It only exposes the
+// operations that move or inspect the read side.
+type Reader struct {
+	rb *RingBuffer
+}
+
+// Writer is the producer half of a RingBuffer. It only exposes the
+// operations that move or inspect the write side.
+type Writer struct {
+	rb *RingBuffer
+}
+
+func (rb *RingBuffer) Reader() Reader {
+	return Reader{rb: rb}
+}
+
+func (rb *RingBuffer) Writer() Writer {
+	return Writer{rb: rb}
+}
+
+func (r Reader) Size() int {
+	return r.rb.Size()
+}
+
+func (r Reader) AvailableReadContiguous() int {
+	return r.rb.AvailableReadContiguous()
+}
+
+func (r Reader) Read(dst []byte) (int, error) {
+	return r.rb.Read(dst)
+}
+
+func (r Reader) ReadExact(n int, dst []byte) (int, error) {
+	return r.rb.ReadExact(n, dst)
+}
+
+func (r Reader) DropOldest(n int) error {
+	return r.rb.DropOldest(n)
+}
+
+func (r Reader) Unread(n int) error {
+	return r.rb.Unread(n)
+}
+
+func (r Reader) UnreadByte() error {
+	return r.rb.UnreadByte()
+}
+
+func (r Reader) HasPrefix(p []byte) bool {
+	return r.rb.HasPrefix(p)
+}
+
+func (r Reader) Bytes() []byte {
+	return r.rb.Bytes()
+}
+
+func (r Reader) ConsumedSequence() uint64 {
+	return r.rb.ConsumedSequence()
+}
+
+func (w Writer) AvailableWriteSize() int {
+	return w.rb.AvailableWriteSize()
+}
+
+func (w Writer) AvailableWriteContiguous() int {
+	return w.rb.AvailableWriteContiguous()
+}
+
+func (w Writer) Write(data []byte) (int, error) {
+	return w.rb.Write(data)
+}
+
+func (w Writer) WriteAvailable(data []byte) int {
+	return w.rb.WriteAvailable(data)
+}
+
+func (w Writer) Fill(b byte, n int) (int, error) {
+	return w.rb.Fill(b, n)
+}
+
+func (w Writer) WriteZeros(n int) (int, error) {
+	return w.rb.WriteZeros(n)
+}
+
+func (w Writer) TruncateNewest(n int) error {
+	return w.rb.TruncateNewest(n)
+}
+
+func (w Writer) WrittenSequence() uint64 {
+	return w.rb.WrittenSequence()
+}
+
+func (w Writer) NotifyConsumed(seq uint64) <-chan struct{} {
+	return w.rb.NotifyConsumed(seq)
+}
+
+func (r Reader) Mark() Marker {
+	return r.rb.Mark()
+}
+
+func (r Reader) RollbackTo(m Marker) error {
+	return r.rb.RollbackTo(m)
+}
+
+func (w Writer) Savepoint() *Savepoint {
+	return w.rb.Savepoint()
+}
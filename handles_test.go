@@ -0,0 +1,43 @@
+package ringbuffer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ReaderWriter(t *testing.T) {
+
+	rb := NewRingBuffer(5)
+	w := rb.Writer()
+	r := rb.Reader()
+
+	nw, err := w.Write([]byte{1, 2, 3})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, nw)
+	assert.Equal(t, 2, w.AvailableWriteSize())
+	done := w.NotifyConsumed(w.WrittenSequence())
+
+	assert.Equal(t, 3, r.Size())
+	assert.True(t, r.HasPrefix([]byte{1, 2}))
+
+	out := make([]byte, 3)
+	nr, err := r.ReadExact(3, out)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, out[:nr])
+	assert.Equal(t, w.WrittenSequence(), r.ConsumedSequence())
+	assert.True(t, isClosed(done))
+
+	assert.Nil(t, r.UnreadByte())
+	assert.Equal(t, []byte{3}, r.Bytes())
+	assert.Nil(t, r.DropOldest(1))
+
+	assert.Equal(t, 2, w.WriteAvailable([]byte{4, 5}))
+	assert.Nil(t, w.TruncateNewest(1))
+	w.WriteZeros(1)
+	assert.Equal(t, []byte{4, 0}, r.Bytes())
+
+	nr, err = r.Read(out)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{4, 0}, out[:nr])
+}
@@ -0,0 +1,42 @@
+package ringbuffer
+
+import "time"
+
+// Hooks receives buffer events so instrumentation can live outside the
+// core package. Callbacks run inline on the calling goroutine and must
+// not call back into the buffer.
+type Hooks interface {
+	// OnWrite is called after n bytes were stored; size is the new fill level.
+	OnWrite(n int, size int)
+	// OnRead is called after n bytes left the buffer by a read, drop or
+	// overwrite; size is the new fill level.
+	OnRead(n int, size int)
+	// OnOverrun is called when an overwrite-mode write discards lost bytes.
+	OnOverrun(lost uint64)
+}
+
+// OccupancyHooks is optionally implemented by a Hooks value to learn of
+// fill level changes that are neither reads nor writes: Reset,
+// ResetKeepStats, TruncateNewest and Unread.
+type OccupancyHooks interface {
+	OnOccupancy(size int)
+}
+
+// LatencyHooks is optionally implemented by a Hooks value to receive
+// the enqueue latency of each write, the delay until its last byte left
+// the buffer. Latency is only measured with WithLatencyHistogram.
+type LatencyHooks interface {
+	OnLatency(d time.Duration)
+}
+
+func WithHooks(h Hooks) Option {
+	return func(rb *RingBuffer) {
+		rb.hooks = h
+	}
+}
+
+func (rb *RingBuffer) occupancyChanged() {
+	if oh, ok := rb.hooks.(OccupancyHooks); ok {
+		oh.OnOccupancy(rb.size)
+	}
+}
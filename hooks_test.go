@@ -0,0 +1,95 @@
+package ringbuffer
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/drgolem/ringbuffer/fakeclock"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingHooks struct {
+	events []string
+}
+
+func (h *recordingHooks) OnWrite(n int, size int) {
+	h.events = append(h.events, fmt.Sprintf("write %d %d", n, size))
+}
+
+func (h *recordingHooks) OnRead(n int, size int) {
+	h.events = append(h.events, fmt.Sprintf("read %d %d", n, size))
+}
+
+func (h *recordingHooks) OnOverrun(lost uint64) {
+	h.events = append(h.events, fmt.Sprintf("overrun %d", lost))
+}
+
+func (h *recordingHooks) OnOccupancy(size int) {
+	h.events = append(h.events, fmt.Sprintf("occupancy %d", size))
+}
+
+func (h *recordingHooks) OnLatency(d time.Duration) {
+	h.events = append(h.events, fmt.Sprintf("latency %v", d))
+}
+
+func Test_Hooks(t *testing.T) {
+
+	h := &recordingHooks{}
+	rb := NewRingBuffer(4, WithOverwrite(), WithHooks(h))
+
+	rb.Write([]byte{1, 2, 3})
+	rb.ReadExact(1, make([]byte, 1))
+	rb.Write([]byte{4, 5, 6})
+
+	assert.Equal(t, []string{
+		"write 3 3",
+		"read 1 2",
+		"read 1 1",
+		"overrun 1",
+		"write 3 4",
+	}, h.events)
+}
+
+func Test_HooksOccupancy(t *testing.T) {
+
+	h := &recordingHooks{}
+	rb := NewRingBuffer(8, WithHooks(h))
+
+	rb.Write([]byte{1, 2, 3, 4})
+	rb.ReadExact(2, make([]byte, 2))
+	rb.Unread(1)
+	rb.TruncateNewest(2)
+	rb.ResetKeepStats()
+	rb.Write([]byte{5})
+	rb.Reset()
+
+	assert.Equal(t, []string{
+		"write 4 4",
+		"read 2 2",
+		"occupancy 3",
+		"occupancy 1",
+		"occupancy 0",
+		"write 1 1",
+		"occupancy 0",
+	}, h.events)
+}
+
+func Test_HooksLatency(t *testing.T) {
+
+	h := &recordingHooks{}
+	clock := fakeclock.New(time.Unix(1000, 0))
+	rb := NewRingBuffer(8, WithHooks(h), WithLatencyHistogram(clock))
+
+	rb.Write([]byte{1, 2})
+	clock.Advance(time.Millisecond)
+	rb.ReadExact(1, make([]byte, 1))
+	rb.ReadExact(1, make([]byte, 1))
+
+	assert.Equal(t, []string{
+		"write 2 2",
+		"read 1 1",
+		"latency 1ms",
+		"read 1 0",
+	}, h.events)
+}
@@ -0,0 +1,39 @@
+//go:build !tinygo
+
+package ringbuffer
+
+import (
+	"io"
+	"net/http"
+)
+
+const defaultStreamChunk = 32 * 1024
+
+// ServeStream drains b into w for serving a live stream, flushing after
+// every chunk of up to chunkSize bytes (32 KiB if zero) when w is an
+// http.Flusher. It returns nil once b is closed and drained, and the
+// request context's error when the client goes away.
+func ServeStream(w http.ResponseWriter, r *http.Request, b *BlockingRingBuffer, chunkSize int) error {
+	if chunkSize <= 0 {
+		chunkSize = defaultStreamChunk
+	}
+	flusher, _ := w.(http.Flusher)
+	buf := make([]byte, chunkSize)
+	for {
+		n, err := b.ReadContext(r.Context(), buf)
+		if n > 0 {
+			if _, werr := w.Write(buf[:n]); werr != nil {
+				return werr
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,45 @@
+//go:build !tinygo
+
+package ringbuffer
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ServeStream(t *testing.T) {
+
+	b := NewBlockingRingBuffer(64, nil)
+	b.Write([]byte("hello "))
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		b.Write([]byte("world"))
+		b.Close()
+	}()
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/stream", nil)
+	assert.Nil(t, ServeStream(w, r, b, 4))
+	assert.Equal(t, "hello world", w.Body.String())
+	assert.True(t, w.Flushed)
+}
+
+func Test_ServeStreamDisconnect(t *testing.T) {
+
+	b := NewBlockingRingBuffer(64, nil)
+	b.Write([]byte("abc"))
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/stream", nil).WithContext(ctx)
+	assert.Equal(t, context.Canceled, ServeStream(w, r, b, 0))
+	assert.Equal(t, "abc", w.Body.String())
+}
@@ -0,0 +1,90 @@
+package ringbuffer
+
+import "io"
+
+// ReadIovec returns up to max zero-copy views of the buffered data, in
+// order, without consuming it. Views are split where the data wraps and,
+// in chunk mode, at chunk boundaries. A max <= 0 returns all views. The
+// views alias the backing array and are only valid until the next write.
+func (rb *RingBuffer) ReadIovec(max int) [][]byte {
+	var iov [][]byte
+	add := func(p []byte) bool {
+		if len(p) == 0 {
+			return true
+		}
+		if max > 0 && len(iov) == max {
+			return false
+		}
+		iov = append(iov, p)
+		return true
+	}
+
+	off := 0
+	for _, end := range rb.iovecEnds() {
+		s1, s2 := rb.readSegments(off, end-off)
+		if !add(s1) || !add(s2) {
+			break
+		}
+		off = end
+	}
+
+	return iov
+}
+
+// ConsumeIovec consumes the bytes covered by views previously returned
+// by ReadIovec.
+func (rb *RingBuffer) ConsumeIovec(iov [][]byte) error {
+	n := 0
+	for _, p := range iov {
+		n += len(p)
+	}
+
+	return rb.DropOldest(n)
+}
+
+// iovecEnds returns the read offsets at which views must end.
+func (rb *RingBuffer) iovecEnds() []int {
+	if !rb.chunked {
+		return []int{rb.size}
+	}
+	ends := make([]int, 0, rb.Chunks())
+	for _, c := range rb.chunkEnds[rb.chunkHead:] {
+		ends = append(ends, int(c.end-rb.consumed))
+	}
+
+	return ends
+}
+
+// WriteTo drains the buffer into w, implementing io.WriterTo. When w is a
+// net.Conn the wrap segments are passed as net.Buffers so the runtime can
+// issue a single writev.
+func (rb *RingBuffer) WriteTo(w io.Writer) (int64, error) {
+	if rb.paused {
+		return 0, ErrPaused
+	}
+	s1, s2 := rb.readSegments(0, rb.size)
+	if len(rb.readXform) > 0 {
+		s1, s2 = rb.Bytes(), nil
+	}
+
+	var n int64
+	var err error
+	if len(s2) > 0 && isConn(w) {
+		n, err = writev(w, s1, s2)
+	} else {
+		for _, p := range [][]byte{s1, s2} {
+			if len(p) == 0 {
+				continue
+			}
+			var m int
+			m, err = w.Write(p)
+			n += int64(m)
+			if err != nil {
+				break
+			}
+		}
+	}
+	rb.advanceRead(int(n))
+
+	return n, err
+}
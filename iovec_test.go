@@ -0,0 +1,97 @@
+package ringbuffer
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ReadIovec(t *testing.T) {
+
+	rb := NewRingBuffer(5)
+	assert.Nil(t, rb.ReadIovec(0))
+
+	rb.Write([]byte{1, 2, 3, 4})
+	rb.DropOldest(3)
+	rb.Write([]byte{5, 6, 7})
+
+	iov := rb.ReadIovec(0)
+	assert.Equal(t, [][]byte{{4, 5}, {6, 7}}, iov)
+	assert.Equal(t, [][]byte{{4, 5}}, rb.ReadIovec(1))
+
+	assert.Nil(t, rb.ConsumeIovec(iov[:1]))
+	assert.Equal(t, []byte{6, 7}, rb.Bytes())
+}
+
+func Test_ReadIovecChunks(t *testing.T) {
+
+	rb := NewRingBuffer(6, WithChunks())
+	rb.Write([]byte{1, 2, 3})
+	rb.Write([]byte{4})
+	rb.DropOldest(3)
+	rb.Write([]byte{5, 6, 7})
+	rb.Write([]byte{8})
+
+	iov := rb.ReadIovec(0)
+	assert.Equal(t, [][]byte{{4}, {5, 6}, {7}, {8}}, iov)
+
+	iov = rb.ReadIovec(2)
+	assert.Equal(t, [][]byte{{4}, {5, 6}}, iov)
+	assert.Nil(t, rb.ConsumeIovec(iov))
+	assert.Equal(t, 2, rb.Chunks())
+	assert.Equal(t, []byte{7, 8}, rb.Bytes())
+}
+
+func Test_WriteTo(t *testing.T) {
+
+	rb := NewRingBuffer(5)
+	rb.Write([]byte{1, 2, 3, 4})
+	rb.DropOldest(3)
+	rb.Write([]byte{5, 6, 7})
+
+	var buf bytes.Buffer
+	n, err := rb.WriteTo(&buf)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(4), n)
+	assert.Equal(t, []byte{4, 5, 6, 7}, buf.Bytes())
+	assert.Equal(t, 0, rb.Size())
+}
+
+func Test_WriteToConn(t *testing.T) {
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skip("no loopback network:", err)
+	}
+	defer ln.Close()
+
+	received := make(chan []byte)
+	go func() {
+		c, err := ln.Accept()
+		if err != nil {
+			received <- nil
+			return
+		}
+		defer c.Close()
+		data, _ := io.ReadAll(c)
+		received <- data
+	}()
+
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	assert.Nil(t, err)
+
+	rb := NewRingBuffer(5)
+	rb.Write([]byte{1, 2, 3, 4})
+	rb.DropOldest(3)
+	rb.Write([]byte{5, 6, 7})
+
+	n, err := rb.WriteTo(conn)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(4), n)
+	conn.Close()
+
+	assert.Equal(t, []byte{4, 5, 6, 7}, <-received)
+}
@@ -0,0 +1,145 @@
+package ringbuffer
+
+import (
+	"time"
+)
+
+// LatencyBuckets is the number of histogram buckets. Bucket 0 counts
+// latencies below 1µs, bucket i counts [2^(i-1)µs, 2^i µs) and the last
+// bucket collects everything above.
+const LatencyBuckets = 32
+
+type LatencyHistogram struct {
+	Counts [LatencyBuckets]uint64
+}
+
+// BucketBound returns the exclusive upper bound of bucket i.
+func (h LatencyHistogram) BucketBound(i int) time.Duration {
+	return time.Microsecond << uint(i)
+}
+
+func (h LatencyHistogram) Count() uint64 {
+	var n uint64
+	for _, c := range h.Counts {
+		n += c
+	}
+
+	return n
+}
+
+// Percentile returns the upper bound of the bucket holding the p-th
+// quantile (0 < p <= 1), or 0 for an empty histogram.
+func (h LatencyHistogram) Percentile(p float64) time.Duration {
+	total := h.Count()
+	if total == 0 {
+		return 0
+	}
+	rank := uint64(p*float64(total) + 0.5)
+	if rank < 1 {
+		rank = 1
+	}
+	var seen uint64
+	for i, c := range h.Counts {
+		seen += c
+		if seen >= rank {
+			return h.BucketBound(i)
+		}
+	}
+
+	return h.BucketBound(LatencyBuckets - 1)
+}
+
+func (h *LatencyHistogram) record(d time.Duration) {
+	i := 0
+	for i < LatencyBuckets-1 && d >= h.BucketBound(i) {
+		i++
+	}
+	h.Counts[i]++
+}
+
+type writeStamp struct {
+	end uint64
+	at  time.Time
+}
+
+type latencyTracker struct {
+	clock  Clock
+	stamps []writeStamp
+	hist   LatencyHistogram
+}
+
+// WithLatencyHistogram timestamps every write and records, per write,
+// the delay until its last byte leaves the buffer (read, dropped or
+// overwritten). A nil clock selects the system clock.
+func WithLatencyHistogram(clock Clock) Option {
+	return func(rb *RingBuffer) {
+		if clock == nil {
+			clock = systemClock{}
+		}
+		rb.lat = &latencyTracker{clock: clock}
+	}
+}
+
+// LatencyHistogram returns a copy of the write-to-read latency histogram.
+func (rb *RingBuffer) LatencyHistogram() LatencyHistogram {
+	if rb.lat == nil {
+		return LatencyHistogram{}
+	}
+
+	return rb.lat.hist
+}
+
+func (lt *latencyTracker) wrote(end uint64) {
+	lt.stamps = append(lt.stamps, writeStamp{end: end, at: lt.clock.Now()})
+}
+
+// consumed records a sample for every write whose last byte is at or
+// before seq and passes it to hooks if they implement LatencyHooks.
+func (lt *latencyTracker) consumed(seq uint64, hooks Hooks) {
+	i := 0
+	var now time.Time
+	var lh LatencyHooks
+	for i < len(lt.stamps) && seqReached(seq, lt.stamps[i].end) {
+		if now.IsZero() {
+			now = lt.clock.Now()
+			lh, _ = hooks.(LatencyHooks)
+		}
+		d := now.Sub(lt.stamps[i].at)
+		lt.hist.record(d)
+		if lh != nil {
+			lh.OnLatency(d)
+		}
+		i++
+	}
+	if i > 0 {
+		k := copy(lt.stamps, lt.stamps[i:])
+		lt.stamps = lt.stamps[:k]
+	}
+}
+
+// retracted forgets stamps of bytes removed by TruncateNewest.
+func (lt *latencyTracker) retracted(written uint64, pending bool) {
+	lt.stamps = retractStamps(lt.stamps, written, pending)
+}
+
+// retractStamps drops the stamps of writes removed entirely by a
+// TruncateNewest back to written. pending reports whether any data is
+// still buffered, which decides the fate of the oldest stamp, whose start
+// is not recorded.
+func retractStamps(stamps []writeStamp, written uint64, pending bool) []writeStamp {
+	k := len(stamps)
+	for k > 0 && !seqReached(written, stamps[k-1].end) {
+		k--
+	}
+	if k < len(stamps) && ((k == 0 && pending) || (k > 0 && stamps[k-1].end != written)) {
+		// the write cut in the middle keeps its stamp, now ending earlier
+		stamps[k].end = written
+		k++
+	}
+
+	return stamps[:k]
+}
+
+func (lt *latencyTracker) reset() {
+	lt.stamps = lt.stamps[:0]
+}
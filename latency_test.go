@@ -0,0 +1,75 @@
+package ringbuffer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/drgolem/ringbuffer/fakeclock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LatencyHistogram(t *testing.T) {
+
+	clock := fakeclock.New(time.Unix(1000, 0))
+	rb := NewRingBuffer(10, WithLatencyHistogram(clock))
+	out := make([]byte, 10)
+
+	rb.Write([]byte{1, 2})
+	clock.Advance(3 * time.Microsecond)
+	rb.Write([]byte{3, 4})
+	clock.Advance(5 * time.Millisecond)
+
+	// the first write is not done until its last byte is read
+	rb.ReadExact(1, out)
+	assert.Equal(t, uint64(0), rb.LatencyHistogram().Count())
+
+	rb.ReadExact(3, out)
+	h := rb.LatencyHistogram()
+	assert.Equal(t, uint64(2), h.Count())
+	assert.Equal(t, 8*time.Millisecond+192*time.Microsecond, h.Percentile(1))
+	assert.Equal(t, h.Percentile(1), h.Percentile(0.5))
+
+	rb.Write([]byte{5})
+	rb.ReadExact(1, out)
+	h = rb.LatencyHistogram()
+	assert.Equal(t, uint64(1), h.Counts[0])
+	assert.Equal(t, time.Microsecond, h.Percentile(0.3))
+}
+
+func Test_LatencyTruncate(t *testing.T) {
+
+	clock := fakeclock.New(time.Unix(1000, 0))
+	rb := NewRingBuffer(10, WithLatencyHistogram(clock))
+	out := make([]byte, 10)
+
+	rb.Write([]byte{1, 2})
+	rb.Write([]byte{3, 4, 5})
+	rb.TruncateNewest(4)
+	rb.Write([]byte{6})
+	assert.Equal(t, 2, len(rb.lat.stamps))
+
+	rb.ReadExact(2, out)
+	assert.Equal(t, uint64(2), rb.LatencyHistogram().Count())
+
+	plain := NewRingBuffer(1)
+	assert.Equal(t, LatencyHistogram{}, plain.LatencyHistogram())
+}
+
+func Test_LatencyTruncateWholeWrite(t *testing.T) {
+
+	clock := fakeclock.New(time.Unix(1000, 0))
+	rb := NewRingBuffer(10, WithLatencyHistogram(clock))
+
+	rb.Write([]byte{1, 2})
+	rb.Write([]byte{3, 4})
+	rb.TruncateNewest(2)
+	assert.Equal(t, 1, len(rb.lat.stamps))
+
+	rb.ReadExact(2, make([]byte, 2))
+	assert.Equal(t, uint64(1), rb.LatencyHistogram().Count())
+
+	// truncating everything leaves no stamp behind
+	rb.Write([]byte{5, 6})
+	rb.TruncateNewest(2)
+	assert.Equal(t, 0, len(rb.lat.stamps))
+}
@@ -0,0 +1,30 @@
+package ringbuffer
+
+import "errors"
+
+// ErrMarkerLost is returned by RollbackTo when bytes consumed since the
+// marker have been overwritten or the buffer was reset.
+var ErrMarkerLost = errors.New("marker no longer recoverable")
+
+// Marker records a read position to roll back to.
+type Marker struct {
+	seq uint64
+}
+
+// Mark returns a marker at the current read position, so a parser can
+// attempt to decode a structure and rewind if it turns out to be
+// incomplete.
+func (rb *RingBuffer) Mark() Marker {
+	return Marker{seq: rb.consumed}
+}
+
+// RollbackTo pushes back every byte consumed since m was taken. It fails
+// with ErrMarkerLost, changing nothing, if writes have reused their space.
+func (rb *RingBuffer) RollbackTo(m Marker) error {
+	n := rb.consumed - m.seq
+	if int64(n) < 0 || n > uint64(rb.unread) {
+		return ErrMarkerLost
+	}
+
+	return rb.Unread(int(n))
+}
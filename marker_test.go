@@ -0,0 +1,39 @@
+package ringbuffer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MarkRollback(t *testing.T) {
+	rb := NewRingBuffer(8)
+	rb.Write([]byte{1, 2, 3, 4, 5})
+	rb.DropOldest(1)
+
+	m := rb.Mark()
+	out := make([]byte, 3)
+	rb.ReadExact(3, out)
+	assert.Equal(t, []byte{2, 3, 4}, out)
+
+	assert.Nil(t, rb.RollbackTo(m))
+	assert.Equal(t, []byte{2, 3, 4, 5}, rb.Bytes())
+
+	assert.Nil(t, rb.RollbackTo(m))
+	assert.Equal(t, 4, rb.Size())
+}
+
+func Test_MarkLost(t *testing.T) {
+	rb := NewRingBuffer(4)
+	rb.Write([]byte{1, 2, 3, 4})
+
+	m := rb.Mark()
+	rb.DropOldest(3)
+	rb.Write([]byte{5, 6})
+	assert.Equal(t, ErrMarkerLost, rb.RollbackTo(m))
+	assert.Equal(t, []byte{4, 5, 6}, rb.Bytes())
+
+	m = rb.Mark()
+	rb.Reset()
+	assert.Equal(t, ErrMarkerLost, rb.RollbackTo(m))
+}
@@ -0,0 +1,103 @@
+package ringbuffer
+
+const defaultMergeQuantum = 4096
+
+// Merger drains several source rings into one destination, visiting the
+// sources round-robin so that no single busy source starves the others.
+type Merger struct {
+	// Quantum caps the bytes moved from one source per turn; chunk-mode
+	// sources move one whole chunk per turn instead. Zero means 4096.
+	Quantum int
+	// Tag writes every piece as a chunk tagged with its source index, for
+	// a chunk-mode destination.
+	Tag bool
+
+	next    int
+	scratch []byte
+}
+
+// Merge drains srcs into dst with a default Merger.
+func Merge(dst *RingBuffer, srcs ...*RingBuffer) (int, error) {
+	var m Merger
+
+	return m.Merge(dst, srcs...)
+}
+
+// Merge moves data until dst is full or no source has anything that
+// fits, and returns the number of bytes moved. The next call resumes the
+// rotation where this one stopped.
+func (m *Merger) Merge(dst *RingBuffer, srcs ...*RingBuffer) (int, error) {
+	quantum := m.Quantum
+	if quantum < 1 {
+		quantum = defaultMergeQuantum
+	}
+
+	total := 0
+	for idle := 0; idle < len(srcs); {
+		i := m.next % len(srcs)
+		m.next = i + 1
+		n, err := m.move(dst, srcs[i], i, quantum)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		if n == 0 {
+			idle++
+		} else {
+			idle = 0
+		}
+	}
+
+	return total, nil
+}
+
+// move copies one piece out of src and consumes it only once dst has
+// accepted it, so a failing destination write loses nothing.
+func (m *Merger) move(dst, src *RingBuffer, idx, quantum int) (int, error) {
+	if m.Tag && !dst.chunked {
+		return 0, dst.violation(ErrNotChunked)
+	}
+	free := dst.AvailableWriteSize()
+	var n int
+	if src.chunked {
+		size, ok := src.NextChunkSize()
+		if !ok || size > free {
+			return 0, nil
+		}
+		n = size
+	} else {
+		n = src.Size()
+		if n > quantum {
+			n = quantum
+		}
+		if n > free {
+			n = free
+		}
+		if src.period > 0 {
+			n -= n % src.period
+		}
+	}
+	if n == 0 || src.paused {
+		return 0, nil
+	}
+
+	if cap(m.scratch) < n {
+		m.scratch = make([]byte, n)
+	}
+	p := m.scratch[:n]
+	src.copyOut(p, 0, n)
+	var w int
+	var err error
+	if m.Tag {
+		w, err = dst.WriteChunk(p, uint32(idx))
+	} else {
+		w, err = dst.Write(p)
+	}
+	if w > 0 {
+		if derr := src.DropOldest(w); derr != nil && err == nil {
+			err = derr
+		}
+	}
+
+	return w, err
+}
@@ -0,0 +1,81 @@
+package ringbuffer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MergeRoundRobin(t *testing.T) {
+
+	a := NewRingBuffer(16)
+	b := NewRingBuffer(16)
+	a.Write([]byte{1, 1, 1, 1, 1, 1})
+	b.Write([]byte{2, 2, 2})
+
+	dst := NewRingBuffer(16)
+	m := Merger{Quantum: 2}
+	n, err := m.Merge(dst, a, b)
+	assert.Nil(t, err)
+	assert.Equal(t, 9, n)
+	assert.Equal(t, []byte{1, 1, 2, 2, 1, 1, 2, 1, 1}, dst.Bytes())
+	assert.Equal(t, 0, a.Size()+b.Size())
+}
+
+func Test_MergeDstFull(t *testing.T) {
+
+	a := NewRingBuffer(16)
+	b := NewRingBuffer(16)
+	a.Write([]byte{1, 1, 1, 1})
+	b.Write([]byte{2, 2, 2, 2})
+
+	dst := NewRingBuffer(5)
+	n, _ := Merge(dst, a, b)
+	assert.Equal(t, 5, n)
+	assert.Equal(t, []byte{1, 1, 1, 1, 2}, dst.Bytes())
+	assert.Equal(t, 3, b.Size())
+}
+
+func Test_MergeTagged(t *testing.T) {
+
+	a := NewRingBuffer(16, WithChunks())
+	b := NewRingBuffer(16)
+	a.Write([]byte{1, 1, 1})
+	a.Write([]byte{1})
+	b.Write([]byte{2, 2})
+
+	dst := NewRingBuffer(16, WithChunks())
+	m := Merger{Tag: true}
+	m.Merge(dst, a, b)
+
+	out := make([]byte, 8)
+	var got [][]byte
+	var tags []uint32
+	for dst.Chunks() > 0 {
+		n, meta, _ := dst.ReadChunkMeta(out)
+		got = append(got, append([]byte(nil), out[:n]...))
+		tags = append(tags, meta)
+	}
+	assert.Equal(t, [][]byte{{1, 1, 1}, {2, 2}, {1}}, got)
+	assert.Equal(t, []uint32{0, 1, 0}, tags)
+}
+
+func Test_MergeDstFailureKeepsData(t *testing.T) {
+
+	a := NewRingBuffer(16)
+	a.Write([]byte{1, 2, 3})
+
+	// tagging needs a chunk-mode destination
+	plain := NewRingBuffer(16)
+	m := Merger{Tag: true}
+	_, err := m.Merge(plain, a)
+	assert.ErrorIs(t, err, ErrNotChunked)
+	assert.Equal(t, []byte{1, 2, 3}, a.Bytes())
+
+	// a destination that rejects the write
+	periodic := NewRingBuffer(16, WithPeriod(2))
+	_, err = Merge(periodic, a)
+	assert.ErrorIs(t, err, ErrNotPeriodAligned)
+	assert.Equal(t, []byte{1, 2, 3}, a.Bytes())
+	assert.Equal(t, 0, periodic.Size())
+}
@@ -0,0 +1,96 @@
+//go:build !tinygo
+
+package ringbuffer
+
+import (
+	"bytes"
+	"encoding/gob"
+)
+
+// MessageCodec marshals typed messages for a MessageRing. Marshal appends
+// the encoding of v to dst and returns it. A protobuf codec is a thin
+// wrapper around proto.MarshalOptions.MarshalAppend and proto.Unmarshal.
+type MessageCodec interface {
+	Marshal(dst []byte, v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// GobCodec encodes every message as a self-contained gob stream, so
+// messages stay decodable on their own even when others are dropped.
+type GobCodec struct {
+	buf bytes.Buffer
+}
+
+func (c *GobCodec) Marshal(dst []byte, v interface{}) ([]byte, error) {
+	c.buf.Reset()
+	if err := gob.NewEncoder(&c.buf).Encode(v); err != nil {
+		return dst, err
+	}
+
+	return append(dst, c.buf.Bytes()...), nil
+}
+
+func (c *GobCodec) Unmarshal(data []byte, v interface{}) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// MessageRing carries typed messages, one chunk each, reusing its
+// marshal buffers between calls.
+type MessageRing struct {
+	rb    *RingBuffer
+	codec MessageCodec
+	enc   []byte
+	dec   []byte
+}
+
+func NewMessageRing(capacity int, codec MessageCodec) *MessageRing {
+	return &MessageRing{
+		rb:    NewRingBuffer(capacity, WithChunks()),
+		codec: codec,
+	}
+}
+
+func (mr *MessageRing) Capacity() int {
+	return mr.rb.Capacity()
+}
+
+// Size is the number of encoded bytes held.
+func (mr *MessageRing) Size() int {
+	return mr.rb.Size()
+}
+
+// Messages reports the number of buffered messages.
+func (mr *MessageRing) Messages() int {
+	return mr.rb.Chunks()
+}
+
+// WriteMessage encodes m and stores it. Nothing is stored if the encoding
+// does not fit.
+func (mr *MessageRing) WriteMessage(m interface{}) error {
+	enc, err := mr.codec.Marshal(mr.enc[:0], m)
+	mr.enc = enc
+	if err != nil {
+		return err
+	}
+	_, err = mr.rb.Write(enc)
+
+	return err
+}
+
+// ReadMessage decodes the oldest message into m. It returns ErrEmpty when
+// no message is buffered. The message is consumed even if decoding fails.
+func (mr *MessageRing) ReadMessage(m interface{}) error {
+	n, ok := mr.rb.NextChunkSize()
+	if !ok {
+		return ErrEmpty
+	}
+	if cap(mr.dec) < n {
+		mr.dec = make([]byte, n)
+	}
+	dec := mr.dec[:n]
+	if _, err := mr.rb.ReadChunk(dec); err != nil {
+		return err
+	}
+
+	return mr.codec.Unmarshal(dec, m)
+}
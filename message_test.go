@@ -0,0 +1,40 @@
+//go:build !tinygo
+
+package ringbuffer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testMessage struct {
+	ID      int
+	Payload []byte
+	Tags    map[string]string
+}
+
+func Test_MessageRingGob(t *testing.T) {
+
+	mr := NewMessageRing(4096, &GobCodec{})
+	for i := 0; i < 3; i++ {
+		err := mr.WriteMessage(testMessage{ID: i, Payload: []byte{byte(i)}, Tags: map[string]string{"k": "v"}})
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, 3, mr.Messages())
+
+	for i := 0; i < 3; i++ {
+		var m testMessage
+		assert.Nil(t, mr.ReadMessage(&m))
+		assert.Equal(t, testMessage{ID: i, Payload: []byte{byte(i)}, Tags: map[string]string{"k": "v"}}, m)
+	}
+	assert.Equal(t, ErrEmpty, mr.ReadMessage(&testMessage{}))
+}
+
+func Test_MessageRingFull(t *testing.T) {
+
+	mr := NewMessageRing(32, &GobCodec{})
+	err := mr.WriteMessage(testMessage{Payload: make([]byte, 64)})
+	assert.Error(t, err)
+	assert.Equal(t, 0, mr.Size())
+}
@@ -0,0 +1,135 @@
+package ringbuffer
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fifoModel is a reference FIFO with the same observable contract as
+// RingBuffer, built on a plain slice.
+type fifoModel struct {
+	capacity int
+	data     []byte
+	history  []byte
+	unread   int
+	written  uint64
+	consumed uint64
+}
+
+func (m *fifoModel) free() int {
+	return m.capacity - len(m.data)
+}
+
+func (m *fifoModel) clampUnread() {
+	if m.unread > m.free() {
+		m.unread = m.free()
+	}
+}
+
+func (m *fifoModel) write(p []byte) bool {
+	if len(p) > m.free() {
+		return false
+	}
+	m.data = append(m.data, p...)
+	m.written += uint64(len(p))
+	m.clampUnread()
+	return true
+}
+
+func (m *fifoModel) consume(n int) ([]byte, bool) {
+	if n < 0 || n > len(m.data) {
+		return nil, false
+	}
+	out := append([]byte(nil), m.data[:n]...)
+	m.data = m.data[n:]
+	m.history = append(m.history, out...)
+	m.consumed += uint64(n)
+	m.unread += n
+	m.clampUnread()
+	return out, true
+}
+
+func (m *fifoModel) truncate(n int) bool {
+	if n < 0 || n > len(m.data) {
+		return false
+	}
+	m.data = m.data[:len(m.data)-n]
+	m.written -= uint64(n)
+	return true
+}
+
+func (m *fifoModel) unreadN(n int) bool {
+	if n < 0 || n > m.unread {
+		return false
+	}
+	back := m.history[len(m.history)-n:]
+	m.data = append(append([]byte(nil), back...), m.data...)
+	m.history = m.history[:len(m.history)-n]
+	m.consumed -= uint64(n)
+	m.unread -= n
+	return true
+}
+
+func Test_ModelRandomOps(t *testing.T) {
+
+	rnd := rand.New(rand.NewSource(1))
+
+	for _, capacity := range []int{1, 2, 7, 16, 100} {
+		rb := NewRingBuffer(capacity)
+		m := &fifoModel{capacity: capacity}
+		var next byte
+
+		for step := 0; step < 5000; step++ {
+			n := rnd.Intn(capacity + 2)
+			switch op := rnd.Intn(7); op {
+			case 0, 1:
+				p := make([]byte, n)
+				for i := range p {
+					p[i] = next
+					next++
+				}
+				_, err := rb.Write(p)
+				assert.Equal(t, m.write(p), err == nil, "write %d", n)
+			case 2:
+				p := make([]byte, n)
+				for i := range p {
+					p[i] = next
+					next++
+				}
+				fits := n
+				if fits > m.free() {
+					fits = m.free()
+				}
+				m.write(p[:fits])
+				assert.Equal(t, fits, rb.WriteAvailable(p))
+			case 3:
+				dst := make([]byte, n)
+				nr, err := rb.ReadExact(n, dst)
+				want, ok := m.consume(n)
+				assert.Equal(t, ok, err == nil, "read %d", n)
+				if ok {
+					assert.True(t, bytes.Equal(want, dst[:nr]), "read %d", n)
+				}
+			case 4:
+				_, ok := m.consume(n)
+				assert.Equal(t, ok, rb.DropOldest(n) == nil, "drop %d", n)
+			case 5:
+				assert.Equal(t, m.truncate(n), rb.TruncateNewest(n) == nil, "truncate %d", n)
+			case 6:
+				n = rnd.Intn(3)
+				assert.Equal(t, m.unreadN(n), rb.Unread(n) == nil, "unread %d", n)
+			}
+
+			if !bytes.Equal(m.data, rb.Bytes()) {
+				t.Fatalf("capacity %d step %d: ring %v, model %v", capacity, step, rb.Bytes(), m.data)
+			}
+			assert.Equal(t, len(m.data), rb.Size())
+			assert.Equal(t, m.free(), rb.AvailableWriteSize())
+			assert.Equal(t, m.written, rb.WrittenSequence())
+			assert.Equal(t, m.consumed, rb.ConsumedSequence())
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package ringbuffer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Mux is the producer side of a ring carrying several logical streams.
+// Every write becomes one chunk tagged with its stream id.
+type Mux struct {
+	c *muxCore
+}
+
+// Demux is the consumer side of a Mux. Reading one stream parks chunks
+// of other streams found ahead of it until those streams are read;
+// parked bytes still count against the capacity, so a stream nobody
+// reads eventually blocks the writers instead of growing memory.
+type Demux struct {
+	c *muxCore
+}
+
+// StreamStats is the flow accounting of one stream.
+type StreamStats struct {
+	Written  uint64
+	Read     uint64
+	Buffered int
+}
+
+type muxCore struct {
+	mu      sync.Mutex
+	rb      *RingBuffer
+	parked  int
+	streams map[uint32]*muxStream
+}
+
+type muxStream struct {
+	stats  StreamStats
+	parked [][]byte
+}
+
+// NewMux returns the two ends of a multiplexed ring; each may be used
+// from its own goroutine.
+func NewMux(capacity int) (*Mux, *Demux) {
+	c := &muxCore{
+		rb:      NewRingBuffer(capacity, WithChunks()),
+		streams: make(map[uint32]*muxStream),
+	}
+
+	return &Mux{c: c}, &Demux{c: c}
+}
+
+func (c *muxCore) stream(id uint32) *muxStream {
+	s, ok := c.streams[id]
+	if !ok {
+		s = &muxStream{}
+		c.streams[id] = s
+	}
+
+	return s
+}
+
+// Write stores data as one chunk of stream id.
+func (m *Mux) Write(id uint32, data []byte) (int, error) {
+	c := m.c
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if free := c.rb.Capacity() - c.rb.Size() - c.parked; len(data) > free {
+		return 0, errors.New(fmt.Sprintf("data len exceed capacity. %d > %d", len(data), free))
+	}
+	n, err := c.rb.WriteChunk(data, id)
+	s := c.stream(id)
+	s.stats.Written += uint64(n)
+	s.stats.Buffered += n
+
+	return n, err
+}
+
+// Read reads the oldest chunk of stream id into dst. It returns ErrEmpty
+// when the stream has nothing buffered and io.ErrShortBuffer, consuming
+// nothing, when dst cannot hold the chunk.
+func (d *Demux) Read(id uint32, dst []byte) (int, error) {
+	c := d.c
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s := c.stream(id)
+	if len(s.parked) > 0 {
+		p := s.parked[0]
+		if len(dst) < len(p) {
+			return 0, io.ErrShortBuffer
+		}
+		s.parked[0] = nil
+		s.parked = s.parked[1:]
+		c.parked -= len(p)
+		s.stats.Read += uint64(len(p))
+		s.stats.Buffered -= len(p)
+
+		return copy(dst, p), nil
+	}
+
+	for {
+		meta, ok := c.rb.NextChunkMeta()
+		if !ok {
+			return 0, ErrEmpty
+		}
+		if meta == id {
+			break
+		}
+		n, _ := c.rb.NextChunkSize()
+		p := make([]byte, n)
+		c.rb.ReadChunk(p)
+		other := c.stream(meta)
+		other.parked = append(other.parked, p)
+		c.parked += n
+	}
+	n, err := c.rb.ReadChunk(dst)
+	if err != nil {
+		return 0, err
+	}
+	s.stats.Read += uint64(n)
+	s.stats.Buffered -= n
+
+	return n, nil
+}
+
+// Stats returns the flow accounting of stream id.
+func (d *Demux) Stats(id uint32) StreamStats {
+	c := d.c
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if s, ok := c.streams[id]; ok {
+		return s.stats
+	}
+
+	return StreamStats{}
+}
@@ -0,0 +1,85 @@
+package ringbuffer
+
+import (
+	"io"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MuxDemux(t *testing.T) {
+
+	mux, demux := NewMux(16)
+	mux.Write(1, []byte{1, 1})
+	mux.Write(2, []byte{2, 2, 2})
+	mux.Write(1, []byte{1})
+	mux.Write(3, []byte{3})
+
+	out := make([]byte, 8)
+	n, err := demux.Read(1, out)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{1, 1}, out[:n])
+	n, _ = demux.Read(1, out)
+	assert.Equal(t, []byte{1}, out[:n])
+	_, err = demux.Read(1, out)
+	assert.Equal(t, ErrEmpty, err)
+
+	assert.Equal(t, StreamStats{Written: 3, Read: 0, Buffered: 3}, demux.Stats(2))
+	_, err = demux.Read(2, out[:2])
+	assert.Equal(t, io.ErrShortBuffer, err)
+	n, _ = demux.Read(2, out)
+	assert.Equal(t, []byte{2, 2, 2}, out[:n])
+	assert.Equal(t, StreamStats{Written: 3, Read: 3, Buffered: 0}, demux.Stats(2))
+
+	n, _ = demux.Read(3, out)
+	assert.Equal(t, []byte{3}, out[:n])
+	assert.Equal(t, StreamStats{}, demux.Stats(9))
+}
+
+func Test_MuxParkedCountsAgainstCapacity(t *testing.T) {
+
+	mux, demux := NewMux(8)
+	mux.Write(1, []byte{1, 1, 1, 1, 1, 1})
+	mux.Write(2, []byte{2})
+
+	_, err := demux.Read(2, make([]byte, 8))
+	assert.Nil(t, err)
+
+	_, err = mux.Write(2, []byte{2, 2, 2})
+	assert.Error(t, err)
+	_, err = mux.Write(2, []byte{2, 2})
+	assert.Nil(t, err)
+}
+
+func Test_MuxConcurrent(t *testing.T) {
+
+	mux, demux := NewMux(64)
+	const total = 2000
+	go func() {
+		for i := 0; i < total; i++ {
+			for {
+				if _, err := mux.Write(uint32(i%2), []byte{byte(i)}); err == nil {
+					break
+				}
+				runtime.Gosched()
+			}
+		}
+	}()
+
+	next := [2]int{0, 1}
+	out := make([]byte, 1)
+	for next[0] < total || next[1] < total {
+		for id := 0; id < 2; id++ {
+			if next[id] >= total {
+				continue
+			}
+			if _, err := demux.Read(uint32(id), out); err == nil {
+				assert.Equal(t, byte(next[id]), out[0])
+				next[id] += 2
+			} else {
+				runtime.Gosched()
+			}
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package ringbuffer
+
+// noCopy makes go vet's copylocks check report copies of the struct
+// that contains it.
+type noCopy struct{}
+
+func (*noCopy) Lock()   {}
+func (*noCopy) Unlock() {}
+
+// copyCheck panics when rb is a copy of a constructed buffer, whose
+// state would diverge from the original's while sharing its storage. A
+// zero RingBuffer adopts its address on first use.
+func (rb *RingBuffer) copyCheck() {
+	if rb.addr == nil {
+		rb.addr = rb
+	} else if rb.addr != rb {
+		panic("ringbuffer: illegal use of a copied RingBuffer")
+	}
+}
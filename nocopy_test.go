@@ -0,0 +1,28 @@
+package ringbuffer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CopyCheck(t *testing.T) {
+
+	rb := NewRingBuffer(8)
+	rb.Write([]byte{1, 2, 3})
+
+	// what `c := *rb` produces; spelled out because vet rejects the copy
+	c := &RingBuffer{addr: rb.addr, ringState: rb.ringState}
+	assert.Panics(t, func() { c.Write([]byte{4}) })
+	assert.Panics(t, func() { c.ReadExact(1, make([]byte, 1)) })
+	assert.Panics(t, func() { c.Reset() })
+
+	clone := rb.Clone()
+	clone.Write([]byte{4})
+	assert.Equal(t, []byte{1, 2, 3}, rb.Bytes())
+	assert.Equal(t, []byte{1, 2, 3, 4}, clone.Bytes())
+
+	var zero RingBuffer
+	zero.Reset()
+	assert.Equal(t, &zero, zero.addr)
+}
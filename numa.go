@@ -0,0 +1,31 @@
+package ringbuffer
+
+type numaBinding struct {
+	node int
+	err  error
+}
+
+// WithNUMANode allocates the backing array on whole pages bound to the
+// given NUMA node, for deployments that pin the producer and consumer to
+// one socket. It is supported on Linux only. When binding fails the
+// buffer falls back to ordinary memory; NewChecked reports the error.
+// The binding applies to the pages, which return to the Go heap with
+// the policy still attached once the buffer is collected.
+func WithNUMANode(node int) Option {
+	return func(rb *RingBuffer) {
+		buf, err := allocOnNode(rb.capacity, node)
+		rb.numa = &numaBinding{node: node, err: err}
+		if err == nil {
+			rb.buf = buf
+		}
+	}
+}
+
+// NUMANode returns the node the backing array is bound to, or -1.
+func (rb *RingBuffer) NUMANode() int {
+	if rb.numa == nil || rb.numa.err != nil {
+		return -1
+	}
+
+	return rb.numa.node
+}
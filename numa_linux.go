@@ -0,0 +1,43 @@
+package ringbuffer
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	mpolBind   = 2
+	mpolMFMove = 1 << 1
+)
+
+// allocOnNode returns n bytes carved from a page-aligned region of the
+// heap whose pages are bound to node with mbind.
+func allocOnNode(n, node int) ([]byte, error) {
+	if node < 0 {
+		return nil, errors.New(fmt.Sprintf("invalid NUMA node %d", node))
+	}
+	if n <= 0 {
+		return nil, nil
+	}
+	page := syscall.Getpagesize()
+	size := (n + page - 1) / page * page
+	raw := make([]byte, size+page)
+	off := 0
+	if rem := int(uintptr(unsafe.Pointer(&raw[0])) % uintptr(page)); rem != 0 {
+		off = page - rem
+	}
+	region := raw[off : off+size]
+
+	mask := make([]uint64, node/64+1)
+	mask[node/64] |= 1 << (node % 64)
+	_, _, errno := syscall.Syscall6(syscall.SYS_MBIND,
+		uintptr(unsafe.Pointer(&region[0])), uintptr(size), mpolBind,
+		uintptr(unsafe.Pointer(&mask[0])), uintptr(len(mask)*64+1), mpolMFMove)
+	if errno != 0 {
+		return nil, errors.New(fmt.Sprintf("mbind to NUMA node %d: %v", node, errno))
+	}
+
+	return region[:n:n], nil
+}
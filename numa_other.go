@@ -0,0 +1,12 @@
+//go:build !linux
+
+package ringbuffer
+
+import (
+	"errors"
+	"runtime"
+)
+
+func allocOnNode(n, node int) ([]byte, error) {
+	return nil, errors.New("NUMA binding is not supported on " + runtime.GOOS)
+}
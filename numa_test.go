@@ -0,0 +1,35 @@
+package ringbuffer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WithNUMANode(t *testing.T) {
+
+	plain := NewRingBuffer(8)
+	assert.Equal(t, -1, plain.NUMANode())
+
+	_, err := NewChecked(8, WithNUMANode(-1))
+	assert.Error(t, err)
+	_, err = NewChecked(8, WithNUMANode(4095))
+	assert.Error(t, err)
+
+	// failed binding falls back to ordinary memory
+	fallback := NewRingBuffer(8, WithNUMANode(4095))
+	assert.Equal(t, -1, fallback.Stats().NUMANode)
+	n, err := fallback.Write([]byte("abc"))
+	assert.Equal(t, 3, n)
+	assert.Nil(t, err)
+
+	rb, err := NewChecked(10000, WithNUMANode(0))
+	if err != nil {
+		t.Skipf("NUMA binding unavailable: %v", err)
+	}
+	assert.Equal(t, 0, rb.Stats().NUMANode)
+	assert.Equal(t, 10000, len(rb.buf))
+	rb.Write(make([]byte, 10000))
+	assert.Equal(t, 10000, rb.Size())
+	assert.Equal(t, -1, rb.Clone().NUMANode())
+}
@@ -0,0 +1,23 @@
+package ringbuffer
+
+// WithOccupancyHistogram samples the fill level after every operation
+// into buckets equal slices of the capacity, reported by Stats as
+// Occupancy. Bucket i counts fill levels in [i, i+1) * Capacity/buckets;
+// a full buffer lands in the last bucket.
+func WithOccupancyHistogram(buckets int) Option {
+	return func(rb *RingBuffer) {
+		if buckets < 1 {
+			buckets = 1
+		}
+		rb.occupancy = make([]uint64, buckets)
+	}
+}
+
+func (rb *RingBuffer) sampleOccupancy() {
+	b := len(rb.occupancy)
+	i := int(int64(rb.size) * int64(b) / int64(rb.capacity))
+	if i >= b {
+		i = b - 1
+	}
+	rb.occupancy[i]++
+}
@@ -0,0 +1,109 @@
+// Package otel records OpenTelemetry metrics for a ringbuffer.RingBuffer
+// through its Hooks interface, keeping the core package free of the
+// dependency. Enqueue latency is recorded for buffers created with
+// ringbuffer.WithLatencyHistogram.
+package otel
+
+import (
+	"context"
+	"time"
+
+	"github.com/drgolem/ringbuffer"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const NameKey = attribute.Key("ringbuffer.name")
+
+// Hooks implements ringbuffer.Hooks, recording byte counters, drops,
+// the occupancy gauge and histogram and the enqueue latency histogram
+// tagged with the buffer name.
+type Hooks struct {
+	written   metric.Int64Counter
+	read      metric.Int64Counter
+	lost      metric.Int64Counter
+	occupancy metric.Int64Gauge
+	fill      metric.Int64Histogram
+	latency   metric.Float64Histogram
+	attrs     metric.MeasurementOption
+}
+
+var (
+	_ ringbuffer.Hooks          = (*Hooks)(nil)
+	_ ringbuffer.OccupancyHooks = (*Hooks)(nil)
+	_ ringbuffer.LatencyHooks   = (*Hooks)(nil)
+)
+
+func New(name string, meter metric.Meter) (*Hooks, error) {
+	written, err := meter.Int64Counter("ringbuffer.bytes.written",
+		metric.WithUnit("By"), metric.WithDescription("Bytes written into the buffer"))
+	if err != nil {
+		return nil, err
+	}
+	read, err := meter.Int64Counter("ringbuffer.bytes.read",
+		metric.WithUnit("By"), metric.WithDescription("Bytes that left the buffer"))
+	if err != nil {
+		return nil, err
+	}
+	lost, err := meter.Int64Counter("ringbuffer.bytes.lost",
+		metric.WithUnit("By"), metric.WithDescription("Bytes discarded by overwrite-mode writes"))
+	if err != nil {
+		return nil, err
+	}
+	occupancy, err := meter.Int64Gauge("ringbuffer.occupancy",
+		metric.WithUnit("By"), metric.WithDescription("Buffered bytes"))
+	if err != nil {
+		return nil, err
+	}
+
+	fill, err := meter.Int64Histogram("ringbuffer.occupancy.distribution",
+		metric.WithUnit("By"), metric.WithDescription("Buffered bytes sampled after every operation"))
+	if err != nil {
+		return nil, err
+	}
+	latency, err := meter.Float64Histogram("ringbuffer.latency",
+		metric.WithUnit("s"), metric.WithDescription("Delay from a write until its last byte left the buffer"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Hooks{
+		written:   written,
+		read:      read,
+		lost:      lost,
+		occupancy: occupancy,
+		fill:      fill,
+		latency:   latency,
+		attrs:     metric.WithAttributeSet(attribute.NewSet(NameKey.String(name))),
+	}, nil
+}
+
+func (h *Hooks) OnWrite(n int, size int) {
+	ctx := context.Background()
+	h.written.Add(ctx, int64(n), h.attrs)
+	h.occupancy.Record(ctx, int64(size), h.attrs)
+	h.fill.Record(ctx, int64(size), h.attrs)
+}
+
+func (h *Hooks) OnRead(n int, size int) {
+	ctx := context.Background()
+	h.read.Add(ctx, int64(n), h.attrs)
+	h.occupancy.Record(ctx, int64(size), h.attrs)
+	h.fill.Record(ctx, int64(size), h.attrs)
+}
+
+// OnOccupancy records the fill level after a reset, truncate or unread.
+func (h *Hooks) OnOccupancy(size int) {
+	ctx := context.Background()
+	h.occupancy.Record(ctx, int64(size), h.attrs)
+	h.fill.Record(ctx, int64(size), h.attrs)
+}
+
+// OnLatency records the enqueue latency of one write.
+func (h *Hooks) OnLatency(d time.Duration) {
+	h.latency.Record(context.Background(), d.Seconds(), h.attrs)
+}
+
+func (h *Hooks) OnOverrun(lost uint64) {
+	h.lost.Add(context.Background(), int64(lost), h.attrs)
+}
@@ -0,0 +1,115 @@
+package otel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/drgolem/ringbuffer"
+	"github.com/drgolem/ringbuffer/fakeclock"
+	"github.com/stretchr/testify/assert"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func Test_Hooks(t *testing.T) {
+
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	h, err := New("capture", provider.Meter("test"))
+	assert.Nil(t, err)
+
+	rb := ringbuffer.NewRingBuffer(4, ringbuffer.WithOverwrite(), ringbuffer.WithHooks(h))
+	rb.Write([]byte{1, 2, 3})
+	rb.ReadExact(1, make([]byte, 1))
+	rb.Write([]byte{4, 5, 6})
+
+	var rm metricdata.ResourceMetrics
+	assert.Nil(t, reader.Collect(context.Background(), &rm))
+
+	got := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, dp := range data.DataPoints {
+					name, _ := dp.Attributes.Value(NameKey)
+					assert.Equal(t, "capture", name.AsString())
+					got[m.Name] = dp.Value
+				}
+			case metricdata.Gauge[int64]:
+				for _, dp := range data.DataPoints {
+					got[m.Name] = dp.Value
+				}
+			case metricdata.Histogram[int64]:
+				for _, dp := range data.DataPoints {
+					got[m.Name+".count"] = int64(dp.Count)
+					got[m.Name+".sum"] = dp.Sum
+				}
+			}
+		}
+	}
+	assert.Equal(t, map[string]int64{
+		"ringbuffer.bytes.written": 6,
+		"ringbuffer.bytes.read":    2,
+		"ringbuffer.bytes.lost":    1,
+		"ringbuffer.occupancy":     4,
+
+		"ringbuffer.occupancy.distribution.count": 4,
+		"ringbuffer.occupancy.distribution.sum":   10,
+	}, got)
+}
+
+func Test_HooksOccupancyAfterReset(t *testing.T) {
+
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	h, err := New("capture", provider.Meter("test"))
+	assert.Nil(t, err)
+
+	rb := ringbuffer.NewRingBuffer(4, ringbuffer.WithHooks(h))
+	rb.Write([]byte{1, 2, 3})
+	rb.Reset()
+
+	var rm metricdata.ResourceMetrics
+	assert.Nil(t, reader.Collect(context.Background(), &rm))
+	gauge := int64(-1)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if data, ok := m.Data.(metricdata.Gauge[int64]); ok && m.Name == "ringbuffer.occupancy" {
+				gauge = data.DataPoints[0].Value
+			}
+		}
+	}
+	assert.Equal(t, int64(0), gauge)
+}
+
+func Test_HooksLatency(t *testing.T) {
+
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	h, err := New("capture", provider.Meter("test"))
+	assert.Nil(t, err)
+
+	clock := fakeclock.New(time.Unix(1000, 0))
+	rb := ringbuffer.NewRingBuffer(8, ringbuffer.WithLatencyHistogram(clock), ringbuffer.WithHooks(h))
+	rb.Write([]byte{1, 2})
+	clock.Advance(2 * time.Millisecond)
+	rb.Write([]byte{3})
+	clock.Advance(2 * time.Millisecond)
+	rb.ReadExact(3, make([]byte, 3))
+
+	var rm metricdata.ResourceMetrics
+	assert.Nil(t, reader.Collect(context.Background(), &rm))
+	var got metricdata.HistogramDataPoint[float64]
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if data, ok := m.Data.(metricdata.Histogram[float64]); ok && m.Name == "ringbuffer.latency" {
+				got = data.DataPoints[0]
+			}
+		}
+	}
+	assert.Equal(t, uint64(2), got.Count)
+	assert.InDelta(t, 0.006, got.Sum, 1e-9)
+}
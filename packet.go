@@ -0,0 +1,175 @@
+package ringbuffer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Packet is one encoded audio frame (Opus, AAC, MP3, ...) with its
+// timing metadata.
+type Packet struct {
+	PTS      time.Duration
+	Duration time.Duration
+	KeyFrame bool
+	Data     []byte
+}
+
+// chunkPad tags filler chunks that keep every packet contiguous.
+const chunkPad = 1
+
+// PacketRing stores opaque encoded frames with their metadata. Every
+// frame is kept contiguous in the backing array, padding over the wrap
+// point when needed, so the next frame can be peeked without copying.
+type PacketRing struct {
+	rb    *RingBuffer
+	hdrs  []Packet
+	head  int
+	epoch time.Time
+}
+
+// ErrNotDue is returned by ReadDue while the oldest packet's presentation
+// time lies in the future.
+var ErrNotDue = errors.New("packet not due")
+
+func NewPacketRing(capacity int) *PacketRing {
+	return &PacketRing{rb: NewRingBuffer(capacity, WithChunks())}
+}
+
+func (pr *PacketRing) Capacity() int {
+	return pr.rb.Capacity()
+}
+
+// Size is the number of bytes held, including wrap padding.
+func (pr *PacketRing) Size() int {
+	return pr.rb.Size()
+}
+
+func (pr *PacketRing) Packets() int {
+	return len(pr.hdrs) - pr.head
+}
+
+// WritePacket copies p into the ring. It fails without storing anything
+// if the frame does not fit contiguously.
+func (pr *PacketRing) WritePacket(p Packet) error {
+	rb := pr.rb
+	n := len(p.Data)
+	if n == 0 {
+		return errors.New("empty packet")
+	}
+	if rb.size == 0 {
+		rb.ResetKeepStats()
+	}
+	pad := 0
+	if rb.writePos+n > rb.capacity {
+		pad = rb.capacity - rb.writePos
+	}
+	if free := rb.capacity - rb.size; pad+n > free {
+		return errors.New(fmt.Sprintf("packet exceeds free space. %d > %d", pad+n, free))
+	}
+	if pad > 0 {
+		rb.chunkMeta = chunkPad
+		rb.Fill(0, pad)
+		rb.chunkMeta = 0
+	}
+	if _, err := rb.Write(p.Data); err != nil {
+		return err
+	}
+	p.Data = nil
+	pr.hdrs = append(pr.hdrs, p)
+
+	return nil
+}
+
+// Peek returns the oldest packet without consuming it. Its Data aliases
+// the ring storage and stays valid until the packet is dropped or read.
+func (pr *PacketRing) Peek() (Packet, bool) {
+	if pr.Packets() == 0 {
+		return Packet{}, false
+	}
+	pr.skipPad()
+	p := pr.hdrs[pr.head]
+	n, _ := pr.rb.NextChunkSize()
+	p.Data, _ = pr.rb.readSegments(0, n)
+
+	return p, true
+}
+
+// ReadPacket copies the oldest packet's data into dst and consumes it.
+// It returns ErrEmpty when no packet is buffered and io.ErrShortBuffer,
+// consuming nothing, when dst is too small.
+func (pr *PacketRing) ReadPacket(dst []byte) (Packet, error) {
+	p, ok := pr.Peek()
+	if !ok {
+		return Packet{}, ErrEmpty
+	}
+	if len(dst) < len(p.Data) {
+		return Packet{}, io.ErrShortBuffer
+	}
+	p.Data = dst[:copy(dst, p.Data)]
+	pr.DropPacket()
+
+	return p, nil
+}
+
+// DropPacket discards the oldest packet.
+func (pr *PacketRing) DropPacket() error {
+	if pr.Packets() == 0 {
+		return ErrEmpty
+	}
+	pr.skipPad()
+	n, _ := pr.rb.NextChunkSize()
+	if err := pr.rb.DropOldest(n); err != nil {
+		return err
+	}
+	pr.hdrs[pr.head] = Packet{}
+	pr.head++
+	if pr.head > len(pr.hdrs)/2 {
+		k := copy(pr.hdrs, pr.hdrs[pr.head:])
+		pr.hdrs = pr.hdrs[:k]
+		pr.head = 0
+	}
+
+	return nil
+}
+
+func (pr *PacketRing) skipPad() {
+	if meta, ok := pr.rb.NextChunkMeta(); ok && meta == chunkPad {
+		n, _ := pr.rb.NextChunkSize()
+		pr.rb.DropOldest(n)
+	}
+}
+
+// SetEpoch sets the wall time at which PTS zero is presented.
+func (pr *PacketRing) SetEpoch(t time.Time) {
+	pr.epoch = t
+}
+
+// NextDue returns when the oldest packet is due, for scheduling the next
+// ReadDue. ok is false when no packet is buffered or no epoch is set.
+func (pr *PacketRing) NextDue() (time.Time, bool) {
+	if pr.Packets() == 0 || pr.epoch.IsZero() {
+		return time.Time{}, false
+	}
+
+	return pr.epoch.Add(pr.hdrs[pr.head].PTS), true
+}
+
+// ReadDue is ReadPacket for a playout queue: it only returns the oldest
+// packet once its presentation time has arrived at now, and ErrNotDue
+// otherwise. Without an epoch, the first call anchors it so that the
+// oldest packet is due at now.
+func (pr *PacketRing) ReadDue(now time.Time, dst []byte) (Packet, error) {
+	if pr.Packets() == 0 {
+		return Packet{}, ErrEmpty
+	}
+	if pr.epoch.IsZero() {
+		pr.epoch = now.Add(-pr.hdrs[pr.head].PTS)
+	}
+	if due, _ := pr.NextDue(); now.Before(due) {
+		return Packet{}, ErrNotDue
+	}
+
+	return pr.ReadPacket(dst)
+}
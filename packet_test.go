@@ -0,0 +1,105 @@
+package ringbuffer
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PacketRing(t *testing.T) {
+
+	pr := NewPacketRing(16)
+	assert.Nil(t, pr.WritePacket(Packet{PTS: 0, Duration: 20 * time.Millisecond, KeyFrame: true, Data: []byte{1, 2, 3, 4, 5}}))
+	assert.Nil(t, pr.WritePacket(Packet{PTS: 20 * time.Millisecond, Duration: 20 * time.Millisecond, Data: []byte{6, 7, 8, 9, 10, 11, 12, 13}}))
+	assert.Equal(t, 2, pr.Packets())
+
+	p, ok := pr.Peek()
+	assert.True(t, ok)
+	assert.True(t, p.KeyFrame)
+	assert.Equal(t, []byte{1, 2, 3, 4, 5}, p.Data)
+	assert.Nil(t, pr.DropPacket())
+
+	// 3 bytes left before the wrap: the next frame is padded over it.
+	assert.Nil(t, pr.WritePacket(Packet{PTS: 40 * time.Millisecond, Data: []byte{14, 15, 16, 17}}))
+	assert.Equal(t, 15, pr.Size())
+
+	out := make([]byte, 8)
+	_, err := pr.ReadPacket(out[:2])
+	assert.Equal(t, io.ErrShortBuffer, err)
+	p, err = pr.ReadPacket(out)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{6, 7, 8, 9, 10, 11, 12, 13}, p.Data)
+	assert.Equal(t, 20*time.Millisecond, p.PTS)
+
+	p, ok = pr.Peek()
+	assert.True(t, ok)
+	assert.Equal(t, []byte{14, 15, 16, 17}, p.Data)
+	assert.Equal(t, 40*time.Millisecond, p.PTS)
+	assert.Nil(t, pr.DropPacket())
+
+	assert.Equal(t, 0, pr.Size())
+	_, ok = pr.Peek()
+	assert.False(t, ok)
+	assert.Equal(t, ErrEmpty, pr.DropPacket())
+}
+
+func Test_PacketRingFull(t *testing.T) {
+
+	pr := NewPacketRing(8)
+	pr.WritePacket(Packet{Data: []byte{1, 2, 3}})
+	pr.WritePacket(Packet{Data: []byte{4, 5, 6}})
+	pr.DropPacket()
+
+	// Would fit in total but not contiguously.
+	assert.Error(t, pr.WritePacket(Packet{Data: []byte{7, 8, 9, 10}}))
+	assert.Nil(t, pr.WritePacket(Packet{Data: []byte{7, 8}}))
+	assert.Equal(t, 2, pr.Packets())
+}
+
+func Test_PacketRingReadDue(t *testing.T) {
+
+	pr := NewPacketRing(64)
+	for i := 0; i < 3; i++ {
+		pr.WritePacket(Packet{PTS: time.Duration(100+20*i) * time.Millisecond, Data: []byte{byte(i)}})
+	}
+	_, ok := pr.NextDue()
+	assert.False(t, ok)
+
+	start := time.Unix(1000, 0)
+	out := make([]byte, 4)
+	p, err := pr.ReadDue(start, out)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0}, p.Data)
+
+	due, ok := pr.NextDue()
+	assert.True(t, ok)
+	assert.Equal(t, start.Add(20*time.Millisecond), due)
+
+	_, err = pr.ReadDue(start.Add(19*time.Millisecond), out)
+	assert.Equal(t, ErrNotDue, err)
+	assert.Equal(t, 2, pr.Packets())
+
+	p, err = pr.ReadDue(start.Add(50*time.Millisecond), out)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{1}, p.Data)
+	p, err = pr.ReadDue(start.Add(50*time.Millisecond), out)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{2}, p.Data)
+	_, err = pr.ReadDue(start.Add(50*time.Millisecond), out)
+	assert.Equal(t, ErrEmpty, err)
+}
+
+func Test_PacketRingSetEpoch(t *testing.T) {
+
+	pr := NewPacketRing(64)
+	pr.WritePacket(Packet{PTS: 40 * time.Millisecond, Data: []byte{1}})
+	epoch := time.Unix(1000, 0)
+	pr.SetEpoch(epoch)
+
+	_, err := pr.ReadDue(epoch, make([]byte, 4))
+	assert.Equal(t, ErrNotDue, err)
+	_, err = pr.ReadDue(epoch.Add(40*time.Millisecond), make([]byte, 4))
+	assert.Nil(t, err)
+}
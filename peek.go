@@ -0,0 +1,81 @@
+package ringbuffer
+
+import (
+	"errors"
+	"io"
+)
+
+// ErrOutOfWindow is returned for positions outside the buffered data:
+// bytes already consumed or, for Window, beyond the newest byte.
+var ErrOutOfWindow = errors.New("position outside buffered window")
+
+// PeekCursor reads and seeks over the buffered data without consuming
+// it. Offsets are relative to the read position at the time the cursor
+// was created; they stay attached to the same bytes while the buffer is
+// written to, and bytes consumed in the meantime can no longer be read.
+type PeekCursor struct {
+	rb      *RingBuffer
+	base    uint64
+	pos     int64
+	bounded bool
+}
+
+func (rb *RingBuffer) PeekCursor() *PeekCursor {
+	return &PeekCursor{rb: rb, base: rb.consumed}
+}
+
+// Window returns an io.ReadSeeker over the buffered data for format
+// probers that sniff the stream head before consumption begins. Unlike a
+// plain PeekCursor it refuses to seek outside the buffered window.
+func (rb *RingBuffer) Window() io.ReadSeeker {
+	return &PeekCursor{rb: rb, base: rb.consumed, bounded: true}
+}
+
+func (pc *PeekCursor) Read(p []byte) (int, error) {
+	off := pc.offset()
+	if off < 0 {
+		return 0, ErrOutOfWindow
+	}
+	if off >= int64(pc.rb.size) {
+		return 0, io.EOF
+	}
+	n := pc.rb.size - int(off)
+	if n > len(p) {
+		n = len(p)
+	}
+	pc.rb.copyOut(p, int(off), n)
+	pc.pos += int64(n)
+
+	return n, nil
+}
+
+func (pc *PeekCursor) Seek(offset int64, whence int) (int64, error) {
+	var pos int64
+	switch whence {
+	case io.SeekStart:
+		pos = offset
+	case io.SeekCurrent:
+		pos = pc.pos + offset
+	case io.SeekEnd:
+		pos = int64(pc.rb.written-pc.base) + offset
+	default:
+		return 0, errors.New("peek cursor: invalid whence")
+	}
+	if pos < 0 {
+		return 0, errors.New("peek cursor: negative position")
+	}
+	if pc.bounded {
+		if off := int64(pc.base-pc.rb.consumed) + pos; off < 0 || off > int64(pc.rb.size) {
+			return 0, ErrOutOfWindow
+		}
+	}
+	pc.pos = pos
+
+	return pos, nil
+}
+
+// offset converts the cursor position to an offset from the current read
+// position.
+func (pc *PeekCursor) offset() int64 {
+	return int64(pc.base-pc.rb.consumed) + pc.pos
+}
@@ -0,0 +1,80 @@
+package ringbuffer
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PeekCursor(t *testing.T) {
+
+	rb := NewRingBuffer(6)
+	rb.Write([]byte{1, 2, 3, 4})
+	rb.DropOldest(3)
+	rb.Write([]byte{5, 6, 7, 8})
+
+	pc := rb.PeekCursor()
+	out := make([]byte, 3)
+	n, err := pc.Read(out)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{4, 5, 6}, out[:n])
+
+	pos, err := pc.Seek(-1, io.SeekEnd)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(4), pos)
+	n, err = pc.Read(out)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{8}, out[:n])
+	_, err = pc.Read(out)
+	assert.ErrorIs(t, err, io.EOF)
+
+	pc.Seek(1, io.SeekStart)
+	pc.Seek(1, io.SeekCurrent)
+	n, _ = pc.Read(out)
+	assert.Equal(t, []byte{6, 7, 8}, out[:n])
+	assert.Equal(t, 5, rb.Size())
+
+	// consuming keeps offsets attached to the same bytes
+	rb.DropOldest(2)
+	pc.Seek(2, io.SeekStart)
+	n, _ = pc.Read(out[:1])
+	assert.Equal(t, []byte{6}, out[:n])
+	pc.Seek(0, io.SeekStart)
+	_, err = pc.Read(out)
+	assert.NotNil(t, err)
+
+	_, err = pc.Seek(-1, io.SeekStart)
+	assert.NotNil(t, err)
+}
+
+func Test_Window(t *testing.T) {
+
+	rb := NewRingBuffer(8)
+	rb.Write([]byte("RIFF1234"))
+
+	w := rb.Window()
+	head := make([]byte, 4)
+	_, err := io.ReadFull(w, head)
+	assert.Nil(t, err)
+	assert.Equal(t, "RIFF", string(head))
+
+	_, err = w.Seek(9, io.SeekStart)
+	assert.ErrorIs(t, err, ErrOutOfWindow)
+	_, err = w.Seek(1, io.SeekEnd)
+	assert.ErrorIs(t, err, ErrOutOfWindow)
+
+	pos, err := w.Seek(0, io.SeekEnd)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(8), pos)
+
+	rb.DropOldest(2)
+	_, err = w.Seek(1, io.SeekStart)
+	assert.ErrorIs(t, err, ErrOutOfWindow)
+	_, err = w.Seek(2, io.SeekStart)
+	assert.Nil(t, err)
+	data, err := io.ReadAll(w)
+	assert.Nil(t, err)
+	assert.Equal(t, "FF1234", string(data))
+	assert.Equal(t, 6, rb.Size())
+}
@@ -0,0 +1,51 @@
+package ringbuffer
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotPeriodAligned is returned when a transfer is not a whole number
+// of periods.
+var ErrNotPeriodAligned = errors.New("transfer not period aligned")
+
+// WithPeriod requires every read, write, drop, truncate and unread to be
+// a multiple of size bytes, the way ALSA and JACK applications reason in
+// periods. The capacity should be a multiple of size; NewChecked
+// enforces it.
+func WithPeriod(size int) Option {
+	return func(rb *RingBuffer) {
+		rb.period = size
+	}
+}
+
+// PeriodSize returns the configured period size, or 0 if unset.
+func (rb *RingBuffer) PeriodSize() int {
+	return rb.period
+}
+
+// PeriodsAvailable returns the number of whole periods ready to read.
+func (rb *RingBuffer) PeriodsAvailable() int {
+	if rb.period == 0 {
+		return 0
+	}
+
+	return rb.size / rb.period
+}
+
+// PeriodsFree returns the number of whole periods that can be written.
+func (rb *RingBuffer) PeriodsFree() int {
+	if rb.period == 0 {
+		return 0
+	}
+
+	return (rb.capacity - rb.size) / rb.period
+}
+
+func (rb *RingBuffer) checkPeriod(n int) error {
+	if rb.period == 0 || n%rb.period == 0 {
+		return nil
+	}
+
+	return rb.violation(fmt.Errorf("%w: period: %d, n: %d", ErrNotPeriodAligned, rb.period, n))
+}
@@ -0,0 +1,52 @@
+package ringbuffer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PeriodAligned(t *testing.T) {
+
+	rb := NewRingBuffer(16, WithPeriod(4))
+	assert.Equal(t, 4, rb.PeriodSize())
+
+	_, err := rb.Write([]byte{1, 2, 3})
+	assert.True(t, errors.Is(err, ErrNotPeriodAligned))
+	assert.Equal(t, 0, rb.Size())
+
+	rb.Write([]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	assert.Equal(t, 2, rb.PeriodsAvailable())
+	assert.Equal(t, 2, rb.PeriodsFree())
+
+	out := make([]byte, 8)
+	_, err = rb.ReadExact(2, out)
+	assert.True(t, errors.Is(err, ErrNotPeriodAligned))
+	n, err := rb.ReadExact(4, out)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, n)
+
+	assert.True(t, errors.Is(rb.DropOldest(1), ErrNotPeriodAligned))
+	assert.True(t, errors.Is(rb.TruncateNewest(3), ErrNotPeriodAligned))
+	assert.True(t, errors.Is(rb.Unread(2), ErrNotPeriodAligned))
+	_, err = rb.Fill(0, 5)
+	assert.True(t, errors.Is(err, ErrNotPeriodAligned))
+
+	assert.Equal(t, 8, rb.WriteAvailable(make([]byte, 10)))
+	assert.Equal(t, 3, rb.PeriodsAvailable())
+	assert.Equal(t, 1, rb.PeriodsFree())
+}
+
+func Test_PeriodCapacity(t *testing.T) {
+
+	_, err := NewChecked(10, WithPeriod(4))
+	assert.True(t, errors.Is(err, ErrInvalidCapacity))
+
+	rb, err := NewChecked(12, WithPeriod(4))
+	assert.Nil(t, err)
+	assert.Equal(t, 3, rb.PeriodsFree())
+
+	plain := NewRingBuffer(8)
+	assert.Equal(t, 0, plain.PeriodsAvailable())
+}
@@ -0,0 +1,114 @@
+package ringbuffer
+
+type PriorityPolicy int
+
+const (
+	// StrictPriority always drains the lowest-numbered non-empty lane.
+	StrictPriority PriorityPolicy = iota
+	// WeightedPriority serves lanes round-robin, giving lane i up to
+	// weights[i] chunks per round.
+	WeightedPriority
+)
+
+// PriorityRing aggregates several lanes, each its own RingBuffer, behind
+// a single read side that picks the next chunk according to a policy.
+type PriorityRing struct {
+	lanes   []*RingBuffer
+	policy  PriorityPolicy
+	weights []int
+	credits []int
+	next    int
+}
+
+func NewPriorityRing(capacity int, lanes int) *PriorityRing {
+	pr := &PriorityRing{
+		lanes:  make([]*RingBuffer, lanes),
+		policy: StrictPriority,
+	}
+	for i := range pr.lanes {
+		pr.lanes[i] = NewRingBuffer(capacity)
+	}
+
+	return pr
+}
+
+func NewWeightedPriorityRing(capacity int, weights ...int) *PriorityRing {
+	pr := NewPriorityRing(capacity, len(weights))
+	pr.policy = WeightedPriority
+	pr.weights = make([]int, len(weights))
+	for i, w := range weights {
+		if w < 1 {
+			w = 1
+		}
+		pr.weights[i] = w
+	}
+	pr.credits = append([]int(nil), pr.weights...)
+
+	return pr
+}
+
+func (pr *PriorityRing) Lanes() int {
+	return len(pr.lanes)
+}
+
+// Lane returns the ring feeding lane i; producers write into it directly.
+func (pr *PriorityRing) Lane(i int) *RingBuffer {
+	return pr.lanes[i]
+}
+
+func (pr *PriorityRing) Size() int {
+	size := 0
+	for _, rb := range pr.lanes {
+		size += rb.Size()
+	}
+
+	return size
+}
+
+// ReadChunk reads up to len(dst) bytes from the lane selected by the
+// policy and reports which lane they came from. It returns ErrEmpty when
+// all lanes are empty.
+func (pr *PriorityRing) ReadChunk(dst []byte) (int, int, error) {
+	lane := pr.pick()
+	if lane < 0 {
+		return -1, 0, ErrEmpty
+	}
+	rb := pr.lanes[lane]
+	n := len(dst)
+	if n > rb.Size() {
+		n = rb.Size()
+	}
+	n, err := rb.ReadExact(n, dst)
+
+	return lane, n, err
+}
+
+func (pr *PriorityRing) pick() int {
+	if pr.policy == StrictPriority {
+		for i, rb := range pr.lanes {
+			if rb.Size() > 0 {
+				return i
+			}
+		}
+		return -1
+	}
+
+	for round := 0; round < 2; round++ {
+		for k := 0; k < len(pr.lanes); k++ {
+			i := (pr.next + k) % len(pr.lanes)
+			if pr.credits[i] == 0 || pr.lanes[i].Size() == 0 {
+				continue
+			}
+			pr.credits[i]--
+			pr.next = i
+			if pr.credits[i] == 0 {
+				pr.next = (i + 1) % len(pr.lanes)
+			}
+			return i
+		}
+		// every lane with data has used its share, start a new round
+		copy(pr.credits, pr.weights)
+	}
+
+	return -1
+}
@@ -0,0 +1,61 @@
+package ringbuffer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PriorityRingStrict(t *testing.T) {
+
+	pr := NewPriorityRing(8, 2)
+	assert.Equal(t, 2, pr.Lanes())
+
+	pr.Lane(1).Write([]byte{10, 11, 12})
+	pr.Lane(0).Write([]byte{1, 2})
+	assert.Equal(t, 5, pr.Size())
+
+	out := make([]byte, 2)
+	lane, n, err := pr.ReadChunk(out)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, lane)
+	assert.Equal(t, []byte{1, 2}, out[:n])
+
+	lane, n, err = pr.ReadChunk(out)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, lane)
+	assert.Equal(t, []byte{10, 11}, out[:n])
+
+	pr.Lane(0).Write([]byte{3})
+	lane, n, err = pr.ReadChunk(out)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, lane)
+	assert.Equal(t, []byte{3}, out[:n])
+
+	lane, n, err = pr.ReadChunk(out)
+	assert.Equal(t, 1, lane)
+	assert.Equal(t, []byte{12}, out[:n])
+
+	lane, n, err = pr.ReadChunk(out)
+	assert.ErrorIs(t, err, ErrEmpty)
+	assert.Equal(t, -1, lane)
+	assert.Equal(t, 0, n)
+}
+
+func Test_PriorityRingWeighted(t *testing.T) {
+
+	pr := NewWeightedPriorityRing(16, 2, 1)
+	pr.Lane(0).Write([]byte{1, 2, 3, 4, 5})
+	pr.Lane(1).Write([]byte{10, 11, 12})
+
+	out := make([]byte, 1)
+	var lanes []int
+	for {
+		lane, _, err := pr.ReadChunk(out)
+		if err != nil {
+			break
+		}
+		lanes = append(lanes, lane)
+	}
+	assert.Equal(t, []int{0, 0, 1, 0, 0, 1, 0, 1}, lanes)
+}
@@ -0,0 +1,97 @@
+package ringbuffer
+
+import "time"
+
+const rateSlots = 10
+
+// rateMeter counts bytes in and out per time slot; the rate is the sum
+// over the slots covering the last window.
+type rateMeter struct {
+	clock Clock
+	slot  time.Duration
+	// cur is the number of the current slot since the epoch; it is only
+	// meaningful once started.
+	cur     int64
+	started bool
+	in      [rateSlots]uint64
+	out     [rateSlots]uint64
+}
+
+// WithRateWindow measures ingress and egress throughput over a sliding
+// window, reported by Stats as IngressRate and EgressRate. Egress counts
+// every byte leaving the buffer, whether read, dropped or overwritten. A
+// nil clock selects the system clock.
+func WithRateWindow(window time.Duration, clock Clock) Option {
+	return func(rb *RingBuffer) {
+		if clock == nil {
+			clock = systemClock{}
+		}
+		slot := window / rateSlots
+		if slot <= 0 {
+			slot = 1
+		}
+		rb.rate = &rateMeter{clock: clock, slot: slot}
+	}
+}
+
+// now returns the number of the slot holding the current time, rounded
+// down so clocks before the epoch get negative slots of full width.
+func (rm *rateMeter) now() int64 {
+	ns, slot := rm.clock.Now().UnixNano(), int64(rm.slot)
+	if ns < 0 {
+		return (ns - slot + 1) / slot
+	}
+
+	return ns / slot
+}
+
+// slotIndex maps a slot number to its counters, also for negative slots.
+func slotIndex(n int64) int {
+	return int(((n % rateSlots) + rateSlots) % rateSlots)
+}
+
+// advance rotates to the slot holding now, clearing the slots skipped.
+func (rm *rateMeter) advance() int {
+	idx := rm.now()
+	if !rm.started || idx-rm.cur >= rateSlots {
+		rm.in, rm.out = [rateSlots]uint64{}, [rateSlots]uint64{}
+		rm.cur = idx
+		rm.started = true
+	}
+	for rm.cur < idx {
+		rm.cur++
+		i := slotIndex(rm.cur)
+		rm.in[i], rm.out[i] = 0, 0
+	}
+
+	return slotIndex(rm.cur)
+}
+
+func (rm *rateMeter) wrote(n int) {
+	rm.in[rm.advance()] += uint64(n)
+}
+
+func (rm *rateMeter) consumed(n int) {
+	rm.out[rm.advance()] += uint64(n)
+}
+
+// rates returns bytes per second in and out over the window ending now.
+// It does not rotate the slots, so reading the rates changes nothing.
+func (rm *rateMeter) rates() (float64, float64) {
+	if !rm.started {
+		return 0, 0
+	}
+	// slots older than the window, or already reused, do not count
+	from := rm.now() - rateSlots + 1
+	if oldest := rm.cur - rateSlots + 1; from < oldest {
+		from = oldest
+	}
+	var in, out uint64
+	for n := from; n <= rm.cur; n++ {
+		in += rm.in[slotIndex(n)]
+		out += rm.out[slotIndex(n)]
+	}
+	window := (rm.slot * rateSlots).Seconds()
+
+	return float64(in) / window, float64(out) / window
+}
@@ -0,0 +1,63 @@
+package ringbuffer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/drgolem/ringbuffer/fakeclock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RateWindow(t *testing.T) {
+
+	clock := fakeclock.New(time.Unix(1000, 0))
+	rb := NewRingBuffer(1024, WithRateWindow(time.Second, clock))
+
+	for i := 0; i < 10; i++ {
+		rb.Write(make([]byte, 100))
+		rb.DropOldest(50)
+		clock.Advance(100 * time.Millisecond)
+	}
+	stats := rb.Stats()
+	assert.InDelta(t, 900.0, stats.IngressRate, 0.001)
+	assert.InDelta(t, 450.0, stats.EgressRate, 0.001)
+
+	clock.Advance(500 * time.Millisecond)
+	stats = rb.Stats()
+	assert.InDelta(t, 400.0, stats.IngressRate, 0.001)
+
+	clock.Advance(time.Hour)
+	stats = rb.Stats()
+	assert.Equal(t, 0.0, stats.IngressRate)
+	assert.Equal(t, 0.0, stats.EgressRate)
+
+	plain := NewRingBuffer(8)
+	assert.Equal(t, 0.0, plain.Stats().IngressRate)
+}
+
+func Test_RateWindowEpochAndBefore(t *testing.T) {
+
+	for _, start := range []time.Time{time.Unix(0, 0), {}} {
+		clock := fakeclock.New(start)
+		rb := NewRingBuffer(1024, WithRateWindow(time.Second, clock))
+
+		// the first slot of the epoch is a real slot, not "not started"
+		rb.Write(make([]byte, 100))
+		rb.Write(make([]byte, 100))
+		clock.Advance(100 * time.Millisecond)
+		rb.Write(make([]byte, 100))
+		assert.InDelta(t, 300.0, rb.Stats().IngressRate, 0.001)
+	}
+}
+
+func Test_RateWindowStatsReadOnly(t *testing.T) {
+
+	clock := fakeclock.New(time.Unix(1000, 0))
+	rb := NewRingBuffer(1024, WithRateWindow(time.Second, clock))
+	rb.Write(make([]byte, 100))
+
+	clock.Advance(300 * time.Millisecond)
+	before := *rb.rate
+	rb.Stats()
+	assert.Equal(t, before, *rb.rate)
+}
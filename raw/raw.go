@@ -0,0 +1,73 @@
+// Package raw exposes the index-level primitives of the lock-free
+// single-producer single-consumer ring behind ringbuffer.AudioRing, for
+// expert users building their own coordination, for example around a
+// foreign scheduler or an audio driver's threads.
+//
+// Indices are absolute byte counts that only grow; positions in the
+// backing array are taken modulo its length. The producer owns the
+// write index and the consumer the read index: each side loads the
+// other's index, touches only the bytes between the two, and then
+// publishes its own index with a store. Nothing here checks that the
+// protocol is followed.
+package raw
+
+import "sync/atomic"
+
+type Ring struct {
+	// write and read come first to stay 64-bit aligned on 32-bit
+	// platforms.
+	write uint64
+	read  uint64
+	buf   []byte
+}
+
+// New returns a ring over buf with both indices at zero.
+func New(buf []byte) *Ring {
+	return &Ring{buf: buf}
+}
+
+// Len returns the capacity in bytes.
+func (r *Ring) Len() int {
+	return len(r.buf)
+}
+
+func (r *Ring) LoadReadIndex() uint64 {
+	return atomic.LoadUint64(&r.read)
+}
+
+func (r *Ring) LoadWriteIndex() uint64 {
+	return atomic.LoadUint64(&r.write)
+}
+
+// StoreReadIndex publishes i as the read index, releasing the bytes
+// before it to the producer.
+func (r *Ring) StoreReadIndex(i uint64) {
+	atomic.StoreUint64(&r.read, i)
+}
+
+// StoreWriteIndex publishes i as the write index, making the bytes
+// before it visible to the consumer.
+func (r *Ring) StoreWriteIndex(i uint64) {
+	atomic.StoreUint64(&r.write, i)
+}
+
+// Buffered returns the bytes between the read and the write index. It
+// is exact when called from either side and a snapshot otherwise.
+func (r *Ring) Buffered() int {
+	return int(r.LoadWriteIndex() - r.LoadReadIndex())
+}
+
+// Slice returns the n bytes starting at absolute index off as up to two
+// views of the backing array, split where they wrap. n must not exceed
+// Len.
+func (r *Ring) Slice(off uint64, n int) ([]byte, []byte) {
+	if n == 0 {
+		return nil, nil
+	}
+	pos := int(off % uint64(len(r.buf)))
+	if pos+n <= len(r.buf) {
+		return r.buf[pos : pos+n], nil
+	}
+
+	return r.buf[pos:], r.buf[:pos+n-len(r.buf)]
+}
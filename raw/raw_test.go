@@ -0,0 +1,79 @@
+package raw
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Slice(t *testing.T) {
+
+	r := New(make([]byte, 8))
+	assert.Equal(t, 8, r.Len())
+
+	s1, s2 := r.Slice(6, 4)
+	assert.Equal(t, 2, len(s1))
+	assert.Equal(t, 2, len(s2))
+	copy(s1, "ab")
+	copy(s2, "cd")
+	r.StoreWriteIndex(10)
+	r.StoreReadIndex(6)
+	assert.Equal(t, 4, r.Buffered())
+
+	s1, s2 = r.Slice(r.LoadReadIndex(), r.Buffered())
+	assert.Equal(t, "abcd", string(s1)+string(s2))
+
+	s1, s2 = r.Slice(17, 3)
+	assert.Equal(t, 3, len(s1))
+	assert.Nil(t, s2)
+
+	s1, s2 = r.Slice(3, 0)
+	assert.Nil(t, s1)
+	assert.Nil(t, s2)
+}
+
+func Test_SPSC(t *testing.T) {
+
+	r := New(make([]byte, 16))
+	const total = 10000
+	done := make(chan []byte)
+	go func() {
+		var out []byte
+		for len(out) < total {
+			rd := r.LoadReadIndex()
+			n := int(r.LoadWriteIndex() - rd)
+			if n == 0 {
+				runtime.Gosched()
+				continue
+			}
+			s1, s2 := r.Slice(rd, n)
+			out = append(append(out, s1...), s2...)
+			r.StoreReadIndex(rd + uint64(n))
+		}
+		done <- out
+	}()
+
+	for w := uint64(0); w < total; {
+		free := r.Len() - int(w-r.LoadReadIndex())
+		if free == 0 {
+			runtime.Gosched()
+			continue
+		}
+		s1, s2 := r.Slice(w, free)
+		for _, s := range [][]byte{s1, s2} {
+			for i := range s {
+				s[i] = byte(w + uint64(i))
+			}
+			w += uint64(len(s))
+		}
+		r.StoreWriteIndex(w)
+	}
+
+	out := <-done
+	for i, b := range out[:total] {
+		if b != byte(i) {
+			t.Fatalf("byte %d = %d", i, b)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package ringbuffer
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ReadRemapped reads whole frames into dst with their channels rearranged
+// in the same pass as the copy: output channel i takes input channel
+// mapping[i], or silence if mapping[i] is negative. It returns the number
+// of bytes written to dst.
+func (ar *AudioRing) ReadRemapped(dst []byte, mapping []int) (int, error) {
+	for _, ch := range mapping {
+		if ch >= ar.format.Channels {
+			return 0, errors.New(fmt.Sprintf("invalid channel %d of %d", ch, ar.format.Channels))
+		}
+	}
+	size := ar.format.BitsPerSample / 8
+	outFrame := len(mapping) * size
+	if outFrame == 0 {
+		return 0, nil
+	}
+
+	c := ar.ring.LoadReadIndex()
+	frames := int(ar.ring.LoadWriteIndex()-c) / ar.frame
+	if max := len(dst) / outFrame; frames > max {
+		frames = max
+	}
+	for f := 0; f < frames; f++ {
+		out := dst[f*outFrame : (f+1)*outFrame]
+		// Frames never straddle the wrap: the capacity is a multiple
+		// of the frame size.
+		frame, _ := ar.ring.Slice(c+uint64(f*ar.frame), ar.frame)
+		for i, ch := range mapping {
+			s := out[i*size : (i+1)*size]
+			if ch < 0 {
+				for j := range s {
+					s[j] = ar.silence
+				}
+				continue
+			}
+			copy(s, frame[ch*size:(ch+1)*size])
+		}
+	}
+	ar.ring.StoreReadIndex(c + uint64(frames*ar.frame))
+
+	return frames * outFrame, nil
+}
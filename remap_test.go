@@ -0,0 +1,45 @@
+package ringbuffer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ReadRemapped(t *testing.T) {
+
+	ar, _ := NewAudioRing(12, PCMFormat{SampleRate: 8000, Channels: 3, BitsPerSample: 16})
+	ar.Write([]byte{0, 0, 0, 0, 0, 0})
+	ar.Read(make([]byte, 6))
+	// Two frames of channels (a, b, c), wrapping around the end.
+	ar.Write([]byte{0xa1, 0xa2, 0xb1, 0xb2, 0xc1, 0xc2, 0xa3, 0xa4, 0xb3, 0xb4, 0xc3, 0xc4})
+
+	out := make([]byte, 16)
+	n, err := ar.ReadRemapped(out, []int{2, 0, 0, -1})
+	assert.Nil(t, err)
+	assert.Equal(t, 16, n)
+	assert.Equal(t, []byte{
+		0xc1, 0xc2, 0xa1, 0xa2, 0xa1, 0xa2, 0, 0,
+		0xc3, 0xc4, 0xa3, 0xa4, 0xa3, 0xa4, 0, 0,
+	}, out)
+	assert.Equal(t, 0, ar.Size())
+
+	_, err = ar.ReadRemapped(out, []int{3})
+	assert.Error(t, err)
+}
+
+func Test_ReadRemappedShortDst(t *testing.T) {
+
+	ar, _ := NewAudioRing(8, PCMFormat{SampleRate: 8000, Channels: 2, BitsPerSample: 8})
+	ar.Write([]byte{1, 2, 3, 4, 5, 6})
+
+	out := make([]byte, 3)
+	n, _ := ar.ReadRemapped(out, []int{1})
+	assert.Equal(t, 3, n)
+	assert.Equal(t, []byte{2, 4, 6}, out)
+
+	ar.Write([]byte{7, 8, 9, 10})
+	n, _ = ar.ReadRemapped(out[:1], []int{0})
+	assert.Equal(t, []byte{7}, out[:n])
+	assert.Equal(t, 2, ar.Size())
+}
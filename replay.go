@@ -0,0 +1,189 @@
+package ringbuffer
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ErrReplayDiverged is returned by Replay when the buffer does not end
+// up in the recorded state after an operation.
+var ErrReplayDiverged = errors.New("replay diverged from recorded log")
+
+// ErrInvalidTrace is returned for trace entries with negative or
+// out-of-range sizes or positions.
+var ErrInvalidTrace = errors.New("invalid trace entry")
+
+// Replay applies a recorded operation log, such as the one returned by
+// DumpTrace, to rb in order, and checks after every step that the read
+// and write positions and the buffered size match the record. rb must
+// have the capacity and options of the buffer the log came from.
+//
+// The buffer is first reset to the state preceding the first entry, so
+// a trace that starts mid-stream replays as well. Logs do not carry
+// data; writes store the low byte of each byte's stream sequence.
+func (rb *RingBuffer) Replay(log []TraceEntry) error {
+	if len(log) == 0 {
+		return nil
+	}
+	for i, e := range log {
+		if !e.valid(rb.capacity) {
+			return fmt.Errorf("%w at step %d: %s %d at read %d write %d buffered %d",
+				ErrInvalidTrace, i, e.Op, e.Size, e.ReadPos, e.WritePos, e.Buffered)
+		}
+	}
+	if err := rb.replayStart(log[0]); err != nil {
+		return err
+	}
+	var scratch []byte
+	for i, e := range log {
+		if cap(scratch) < e.Size {
+			scratch = make([]byte, e.Size)
+		}
+		if err := rb.replayOp(e, scratch[:e.Size]); err != nil {
+			return fmt.Errorf("replay step %d (%s %d): %w", i, e.Op, e.Size, err)
+		}
+		if rb.readPos != e.ReadPos || rb.writePos != e.WritePos || rb.size != e.Buffered {
+			return fmt.Errorf("%w at step %d (%s %d): read %d write %d buffered %d, recorded %d %d %d",
+				ErrReplayDiverged, i, e.Op, e.Size, rb.readPos, rb.writePos, rb.size,
+				e.ReadPos, e.WritePos, e.Buffered)
+		}
+	}
+
+	return nil
+}
+
+// replayStart resets rb and positions it where it was before e ran.
+func (rb *RingBuffer) replayStart(e TraceEntry) error {
+	readPos, writePos, size := e.ReadPos, e.WritePos, e.Buffered
+	switch e.Op {
+	case TraceWrite:
+		writePos -= e.Size
+		size -= e.Size
+	case TraceRead, TraceDrop:
+		readPos -= e.Size
+		size += e.Size
+	case TraceTruncate:
+		writePos += e.Size
+		size += e.Size
+	case TraceUnread:
+		readPos += e.Size
+		size -= e.Size
+	case TraceReset:
+		readPos, writePos, size = 0, 0, 0
+	}
+	readPos = ((readPos % rb.capacity) + rb.capacity) % rb.capacity
+	writePos = ((writePos % rb.capacity) + rb.capacity) % rb.capacity
+	if size < 0 || size > rb.capacity || (readPos+size)%rb.capacity != writePos ||
+		e.ReadPos >= rb.capacity || e.WritePos >= rb.capacity {
+		return errors.New(fmt.Sprintf("cannot derive replay start from %s %d at read %d write %d buffered %d",
+			e.Op, e.Size, e.ReadPos, e.WritePos, e.Buffered))
+	}
+
+	rb.Reset()
+	rb.readPos = readPos
+	rb.writePos = writePos
+	rb.size = size
+	rb.written = uint64(size)
+	rb.unread = rb.capacity - size
+	if rb.chunked && size > 0 {
+		rb.chunkEnds = append(rb.chunkEnds, chunkEntry{end: rb.written})
+	}
+
+	return nil
+}
+
+// valid reports whether e could have been recorded on a buffer of the
+// given capacity.
+func (e TraceEntry) valid(capacity int) bool {
+	return e.Size >= 0 && e.Size <= capacity &&
+		e.ReadPos >= 0 && e.ReadPos < capacity &&
+		e.WritePos >= 0 && e.WritePos < capacity &&
+		e.Buffered >= 0 && e.Buffered <= capacity
+}
+
+func (rb *RingBuffer) replayOp(e TraceEntry, scratch []byte) error {
+	switch e.Op {
+	case TraceWrite:
+		for i := range scratch {
+			scratch[i] = byte(rb.written + uint64(i))
+		}
+		_, err := rb.Write(scratch)
+		return err
+	case TraceRead:
+		_, err := rb.ReadExact(e.Size, scratch)
+		return err
+	case TraceDrop:
+		return rb.DropOldest(e.Size)
+	case TraceTruncate:
+		return rb.TruncateNewest(e.Size)
+	case TraceUnread:
+		return rb.Unread(e.Size)
+	case TraceReset:
+		rb.Reset()
+		return nil
+	}
+
+	return errors.New(fmt.Sprintf("unknown trace op %d", e.Op))
+}
+
+// EncodeTrace writes entries one per line in a text form that can be
+// attached to bug reports and read back with DecodeTrace.
+func EncodeTrace(w io.Writer, entries []TraceEntry) error {
+	bw := bufio.NewWriter(w)
+	for _, e := range entries {
+		fmt.Fprintf(bw, "%s %d %d %d %d %d %s\n", e.Op, e.Size, e.ReadPos, e.WritePos,
+			e.Buffered, e.Goroutine, e.Time.Format(time.RFC3339Nano))
+	}
+
+	return bw.Flush()
+}
+
+// DecodeTrace parses the output of EncodeTrace. Blank lines and lines
+// starting with # are skipped. Traces are treated as untrusted input:
+// negative sizes and positions are rejected with ErrInvalidTrace, and
+// Replay checks the rest against the buffer's capacity.
+func DecodeTrace(r io.Reader) ([]TraceEntry, error) {
+	var entries []TraceEntry
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		text := strings.TrimSpace(sc.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		var e TraceEntry
+		var op, ts string
+		if _, err := fmt.Sscanf(text, "%s %d %d %d %d %d %s", &op, &e.Size, &e.ReadPos,
+			&e.WritePos, &e.Buffered, &e.Goroutine, &ts); err != nil {
+			return nil, errors.New(fmt.Sprintf("trace line %d: %v", line, err))
+		}
+		if e.Size < 0 || e.ReadPos < 0 || e.WritePos < 0 || e.Buffered < 0 {
+			return nil, fmt.Errorf("%w: trace line %d: negative size or position", ErrInvalidTrace, line)
+		}
+		e.Op = parseTraceOp(op)
+		if e.Op < 0 {
+			return nil, errors.New(fmt.Sprintf("trace line %d: unknown op %q", line, op))
+		}
+		t, err := time.Parse(time.RFC3339Nano, ts)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("trace line %d: %v", line, err))
+		}
+		e.Time = t
+		entries = append(entries, e)
+	}
+
+	return entries, sc.Err()
+}
+
+func parseTraceOp(s string) TraceOp {
+	for op := TraceWrite; op <= TraceReset; op++ {
+		if op.String() == s {
+			return op
+		}
+	}
+
+	return -1
+}
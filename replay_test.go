@@ -0,0 +1,107 @@
+package ringbuffer
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/drgolem/ringbuffer/fakeclock"
+	"github.com/stretchr/testify/assert"
+)
+
+func recordTrace() []TraceEntry {
+	rb := NewRingBuffer(8, WithTrace(5, fakeclock.New(time.Unix(1000, 0))))
+	rb.Write([]byte("abcdef"))
+	rb.ReadExact(4, make([]byte, 4))
+	rb.Write([]byte("ghijk"))
+	rb.ReadExact(3, make([]byte, 3))
+	rb.Unread(2)
+	rb.DropOldest(5)
+	rb.TruncateNewest(1)
+	rb.Write([]byte("lmn"))
+
+	return rb.DumpTrace()
+}
+
+func Test_Replay(t *testing.T) {
+
+	trace := recordTrace()
+	assert.Equal(t, 5, len(trace))
+	assert.Equal(t, TraceRead, trace[0].Op)
+
+	rb := NewRingBuffer(8)
+	assert.NoError(t, rb.Replay(trace))
+	last := trace[len(trace)-1]
+	assert.Equal(t, last.Buffered, rb.Size())
+	assert.Equal(t, last.WritePos, rb.writePos)
+
+	assert.NoError(t, rb.Replay(nil))
+}
+
+func Test_ReplayDiverged(t *testing.T) {
+
+	trace := recordTrace()
+	trace[2].WritePos++
+	rb := NewRingBuffer(8)
+	err := rb.Replay(trace)
+	assert.True(t, errors.Is(err, ErrReplayDiverged))
+	assert.Contains(t, err.Error(), "step 2")
+
+	// an operation the buffer rejects
+	trace = recordTrace()
+	trace[1].Size = 7
+	err = rb.Replay(trace)
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, ErrReplayDiverged))
+
+	// positions that do not fit the capacity
+	small := NewRingBuffer(4)
+	assert.Error(t, small.Replay(recordTrace()))
+}
+
+func Test_EncodeDecodeTrace(t *testing.T) {
+
+	trace := recordTrace()
+	var buf bytes.Buffer
+	assert.NoError(t, EncodeTrace(&buf, trace))
+	assert.Equal(t, 5, bytes.Count(buf.Bytes(), []byte("\n")))
+
+	decoded, err := DecodeTrace(bytes.NewReader(append([]byte("# from prod\n\n"), buf.Bytes()...)))
+	assert.NoError(t, err)
+	assert.Equal(t, len(trace), len(decoded))
+	for i := range trace {
+		assert.True(t, trace[i].Time.Equal(decoded[i].Time))
+		decoded[i].Time = trace[i].Time
+	}
+	assert.Equal(t, trace, decoded)
+
+	rb := NewRingBuffer(8)
+	assert.NoError(t, rb.Replay(decoded))
+
+	_, err = DecodeTrace(bytes.NewReader([]byte("jump 1 2 3 4 5 2000-01-01T00:00:00Z\n")))
+	assert.Error(t, err)
+	_, err = DecodeTrace(bytes.NewReader([]byte("write x\n")))
+	assert.Error(t, err)
+}
+
+func Test_ReplayInvalidTrace(t *testing.T) {
+
+	_, err := DecodeTrace(bytes.NewReader([]byte("write -1 0 0 0 1 2000-01-01T00:00:00Z\n")))
+	assert.True(t, errors.Is(err, ErrInvalidTrace))
+
+	rb := NewRingBuffer(8)
+	for _, e := range []TraceEntry{
+		{Op: TraceWrite, Size: -1},
+		{Op: TraceRead, Size: 1, ReadPos: -1, WritePos: 0, Buffered: 0},
+		{Op: TraceWrite, Size: MaxCapacity + 1, WritePos: 1, Buffered: 1},
+		{Op: TraceWrite, Size: 1, WritePos: 1, Buffered: -3},
+	} {
+		assert.True(t, errors.Is(rb.Replay([]TraceEntry{e}), ErrInvalidTrace))
+	}
+
+	// a bad entry after a valid start is rejected before anything runs
+	trace := recordTrace()
+	trace[3].Size = -2
+	assert.True(t, errors.Is(rb.Replay(trace), ErrInvalidTrace))
+}
@@ -0,0 +1,43 @@
+package ringbuffer
+
+// Resampler converts between the ring's input rate and the consumer's
+// output rate on the read path, so adaptive-rate playback can pull
+// output frames straight from the ring. Implementations run inside
+// FillOutput and must not block or allocate.
+type Resampler interface {
+	// InputFrames returns how many input frames are needed to produce
+	// out output frames.
+	InputFrames(out int) int
+	// Process resamples whole frames from in into out and returns the
+	// number of bytes consumed from in and produced into out.
+	Process(out, in []byte) (consumed, produced int)
+}
+
+// SetResampler routes FillOutput and Read through r. It must be called
+// before the ring is in use. Drift compensation is skipped while a
+// resampler is set; adapt its ratio instead.
+func (ar *AudioRing) SetResampler(r Resampler) {
+	ar.rs = r
+	ar.scratch = make([]byte, ar.ring.Len())
+}
+
+func (ar *AudioRing) popResampled(dst []byte) int {
+	c := ar.ring.LoadReadIndex()
+	avail := int(ar.ring.LoadWriteIndex() - c)
+	out := len(dst) - len(dst)%ar.frame
+	need := ar.rs.InputFrames(out/ar.frame) * ar.frame
+	if need > avail {
+		need = avail - avail%ar.frame
+	}
+
+	in, wrapped := ar.ring.Slice(c, need)
+	if len(wrapped) > 0 {
+		m := copy(ar.scratch, in)
+		copy(ar.scratch[m:need], wrapped)
+		in = ar.scratch[:need]
+	}
+	consumed, produced := ar.rs.Process(dst[:out], in)
+	ar.ring.StoreReadIndex(c + uint64(consumed))
+
+	return produced
+}
@@ -0,0 +1,61 @@
+package ringbuffer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// halfRate keeps every other 8-bit mono frame.
+type halfRate struct{}
+
+func (halfRate) InputFrames(out int) int {
+	return 2 * out
+}
+
+func (halfRate) Process(out, in []byte) (int, int) {
+	n := len(in) / 2
+	if n > len(out) {
+		n = len(out)
+	}
+	for i := 0; i < n; i++ {
+		out[i] = in[2*i]
+	}
+
+	return 2 * n, n
+}
+
+func Test_ResamplerFillOutput(t *testing.T) {
+
+	ar, _ := NewAudioRing(8, mono8)
+	ar.SetResampler(halfRate{})
+	ar.Write([]byte{1, 2, 3, 4, 5, 6})
+
+	out := make([]byte, 2)
+	filled, underrun := ar.FillOutput(out)
+	assert.Equal(t, 2, filled)
+	assert.False(t, underrun)
+	assert.Equal(t, []byte{1, 3}, out)
+
+	// The next input wraps around the end of the ring.
+	ar.Write([]byte{7, 8, 9, 10})
+	out = make([]byte, 5)
+	filled, underrun = ar.FillOutput(out)
+	assert.Equal(t, 3, filled)
+	assert.True(t, underrun)
+	assert.Equal(t, []byte{5, 7, 9, 0x80, 0x80}, out)
+	assert.Equal(t, 0, ar.Size())
+}
+
+func Test_ResamplerNoAllocs(t *testing.T) {
+
+	ar, _ := NewAudioRing(64, mono8)
+	ar.SetResampler(halfRate{})
+	in := make([]byte, 24)
+	out := make([]byte, 12)
+	allocs := testing.AllocsPerRun(100, func() {
+		ar.PushInput(in)
+		ar.FillOutput(out)
+	})
+	assert.Equal(t, 0.0, allocs)
+}
@@ -1,27 +1,171 @@
 package ringbuffer
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
 )
 
+const MaxCapacity = 1 << 30
+
+var (
+	ErrInvalidCapacity = errors.New("invalid capacity")
+	ErrPaused          = errors.New("ring buffer paused")
+	ErrEmpty           = errors.New("ring buffer empty")
+)
+
+// RingBuffer must not be copied after construction: NewRingBuffer
+// returns a pointer, go vet's copylocks check flags copies, and a copied
+// buffer panics on its next read or write instead of corrupting data.
+// Use Clone for an independent copy.
 type RingBuffer struct {
+	noCopy noCopy
+	addr   *RingBuffer
+	ringState
+}
+
+type ringState struct {
 	buf      []byte
 	capacity int
 	readPos  int
 	writePos int
 	size     int
+	unread   int
+	written  uint64
+	consumed uint64
+	resets   uint64
+	waiters  []consumedWaiter
+	paused   bool
+	strict   bool
+	bp       *backpressure
+	sizer    *sizeAnalyzer
+	lat      *latencyTracker
+	ttl      *ttlTracker
+	rate     *rateMeter
+	hooks    Hooks
+	trace    *traceRing
+	numa     *numaBinding
+
+	occupancy []uint64
+
+	writeXform []Transformer
+	readXform  []Transformer
+	enc        *ctrCipher
+
+	chunked   bool
+	chunkEnds []chunkEntry
+	chunkHead int
+	chunkMeta uint32
+
+	period int
+
+	overwrite bool
+	latest    bool
+	lost      uint64
+	onOverrun func(lost uint64)
 }
 
-func NewRingBuffer(capacity int) RingBuffer {
-	rb := RingBuffer{
-		capacity: capacity,
-		buf:      make([]byte, capacity),
+type Option func(*RingBuffer)
+
+// WithOverwrite makes Write always succeed by discarding the oldest
+// buffered bytes when there is not enough space. Discarded bytes are
+// counted in LostBytes.
+func WithOverwrite() Option {
+	return func(rb *RingBuffer) {
+		rb.overwrite = true
 	}
+}
+
+// WithKeepLatest configures the buffer for "latest value wins" telemetry:
+// writes always succeed and only the newest Capacity() bytes are kept.
+// Unlike WithOverwrite, discarding is the expected steady state and is
+// not counted as loss.
+func WithKeepLatest() Option {
+	return func(rb *RingBuffer) {
+		rb.overwrite = true
+		rb.latest = true
+	}
+}
+
+// OnOverrun sets a callback invoked with the number of bytes lost each
+// time an overwrite-mode Write discards data.
+func OnOverrun(fn func(lost uint64)) Option {
+	return func(rb *RingBuffer) {
+		rb.onOverrun = fn
+	}
+}
+
+// WithStrict makes contract violations (reading or dropping more than is
+// buffered, writing more than fits, a dst too small for n, unreading
+// more than is recoverable) panic instead of returning an error, to
+// catch bugs early in development.
+func WithStrict() Option {
+	return func(rb *RingBuffer) {
+		rb.strict = true
+	}
+}
+
+// WithBuffer stores the data in buf instead of allocating, so the ring
+// can live in statically allocated memory on embedded targets. buf must
+// hold at least capacity bytes; NewChecked rejects a shorter one and
+// NewRingBuffer allocates instead.
+func WithBuffer(buf []byte) Option {
+	return func(rb *RingBuffer) {
+		rb.buf = buf
+	}
+}
+
+type consumedWaiter struct {
+	seq  uint64
+	done chan struct{}
+}
+
+func NewRingBuffer(capacity int, opts ...Option) *RingBuffer {
+	rb := newRingBuffer(capacity, opts)
+	if len(rb.buf) < capacity {
+		rb.buf = make([]byte, capacity)
+	}
+	rb.buf = rb.buf[:capacity]
 
 	return rb
 }
 
+// newRingBuffer applies opts without providing the backing array.
+func newRingBuffer(capacity int, opts []Option) *RingBuffer {
+	rb := &RingBuffer{ringState: ringState{capacity: capacity}}
+	rb.addr = rb
+	for _, opt := range opts {
+		opt(rb)
+	}
+
+	return rb
+}
+
+// NewChecked is like NewRingBuffer but rejects capacities outside
+// (0, MaxCapacity]. The capacity is used as given, never rounded.
+func NewChecked(capacity int, opts ...Option) (*RingBuffer, error) {
+	if capacity <= 0 || capacity > MaxCapacity {
+		return nil, fmt.Errorf("%w: %d, must be in range 1..%d", ErrInvalidCapacity, capacity, MaxCapacity)
+	}
+	rb := newRingBuffer(capacity, opts)
+	if rb.buf == nil {
+		rb.buf = make([]byte, capacity)
+	}
+	if len(rb.buf) < capacity {
+		return nil, fmt.Errorf("%w: %d, buffer holds only %d bytes", ErrInvalidCapacity, capacity, len(rb.buf))
+	}
+	rb.buf = rb.buf[:capacity]
+	if rb.numa != nil && rb.numa.err != nil {
+		return nil, rb.numa.err
+	}
+	if rb.period < 0 || rb.period > 0 && capacity%rb.period != 0 {
+		return nil, fmt.Errorf("%w: %d, must be a multiple of period %d", ErrInvalidCapacity, capacity, rb.period)
+	}
+
+	return rb, nil
+}
+
 func (rb *RingBuffer) Capacity() int {
 	return rb.capacity
 }
@@ -34,54 +178,606 @@ func (rb *RingBuffer) AvailableWriteSize() int {
 	return rb.capacity - rb.size
 }
 
+// AvailableWriteContiguous returns the free space before the write side
+// wraps: the largest write that lands in one contiguous region of the
+// backing array, as single-shot DMA or syscall transfers need.
+func (rb *RingBuffer) AvailableWriteContiguous() int {
+	free := rb.capacity - rb.size
+	if end := rb.capacity - rb.writePos; free > end {
+		return end
+	}
+
+	return free
+}
+
+// AvailableReadContiguous returns the buffered bytes before the read
+// side wraps.
+func (rb *RingBuffer) AvailableReadContiguous() int {
+	if end := rb.capacity - rb.readPos; rb.size > end {
+		return end
+	}
+
+	return rb.size
+}
+
 func (rb *RingBuffer) IsFull() bool {
 	return (rb.capacity - rb.size) == 0
 }
 
+// LostBytes is the cumulative number of bytes discarded by overwrite-mode
+// writes before the consumer could read them.
+func (rb *RingBuffer) LostBytes() uint64 {
+	return rb.lost
+}
+
+// WrittenSequence is the stream offset just past the newest written byte.
+func (rb *RingBuffer) WrittenSequence() uint64 {
+	return rb.written
+}
+
+// ConsumedSequence is the stream offset just past the last byte the
+// consumer has read or dropped. WrittenSequence() - ConsumedSequence()
+// always equals Size(), also after the counters wrap around 2^64.
+func (rb *RingBuffer) ConsumedSequence() uint64 {
+	return rb.consumed
+}
+
+// NotifyConsumed returns a channel that is closed once ConsumedSequence
+// reaches seq, i.e. once everything written before seq has been drained.
+// Reset discards the data and releases all pending notifications.
+func (rb *RingBuffer) NotifyConsumed(seq uint64) <-chan struct{} {
+	done := make(chan struct{})
+	if seqReached(rb.consumed, seq) {
+		close(done)
+		return done
+	}
+	rb.waiters = append(rb.waiters, consumedWaiter{seq: seq, done: done})
+
+	return done
+}
+
+func (rb *RingBuffer) notifyWaiters() {
+	pending := rb.waiters[:0]
+	for _, w := range rb.waiters {
+		if seqReached(rb.consumed, w.seq) {
+			close(w.done)
+			continue
+		}
+		pending = append(pending, w)
+	}
+	for i := len(pending); i < len(rb.waiters); i++ {
+		rb.waiters[i] = consumedWaiter{}
+	}
+	rb.waiters = pending
+}
+
+// seqReached reports whether sequence a is at or past b. Sequences are
+// compared modulo 2^64 so streams stay correct after the counters wrap.
+func seqReached(a, b uint64) bool {
+	return int64(a-b) >= 0
+}
+
+func (rb *RingBuffer) releaseWaiters() {
+	for _, w := range rb.waiters {
+		close(w.done)
+	}
+	rb.waiters = nil
+}
+
+// Pause freezes consumption: Read and DropOldest fail with ErrPaused
+// until Resume, while writes keep buffering.
+func (rb *RingBuffer) Pause() {
+	rb.paused = true
+}
+
+func (rb *RingBuffer) Resume() {
+	rb.paused = false
+}
+
+func (rb *RingBuffer) IsPaused() bool {
+	return rb.paused
+}
+
 func (rb *RingBuffer) Reset() {
+	rb.copyCheck()
 	rb.size = 0
 	rb.readPos = 0
 	rb.writePos = 0
+	rb.unread = 0
+	rb.written = 0
+	rb.consumed = 0
+	rb.resets++
+	rb.chunkEnds = rb.chunkEnds[:0]
+	rb.chunkHead = 0
+	if rb.lat != nil {
+		rb.lat.reset()
+	}
+	if rb.ttl != nil {
+		rb.ttl.stamps = rb.ttl.stamps[:0]
+	}
+	if rb.enc != nil {
+		rb.enc.rekey()
+	}
+	rb.releaseWaiters()
+	rb.occupancyChanged()
+	rb.checkBackpressure()
+	rb.traceOp(TraceReset, 0)
 }
 
-func (rb *RingBuffer) Read(n int, dst []byte) (int, error) {
-	if rb.size < n {
-		return 0, errors.New(fmt.Sprintf("invalid n. sz: %d, n: %d", rb.size, n))
+// ResetKeepStats discards buffered data like Reset but keeps the
+// cumulative sequences; the discarded bytes count as consumed.
+func (rb *RingBuffer) ResetKeepStats() {
+	rb.size = 0
+	rb.readPos = 0
+	rb.writePos = 0
+	rb.unread = 0
+	rb.consumed = rb.written
+	rb.resets++
+	rb.chunkEnds = rb.chunkEnds[:0]
+	rb.chunkHead = 0
+	if rb.lat != nil {
+		rb.lat.reset()
+	}
+	if rb.ttl != nil {
+		rb.ttl.stamps = rb.ttl.stamps[:0]
 	}
-	if rb.readPos+n <= rb.capacity {
-		copy(dst, rb.buf[rb.readPos:rb.readPos+n])
-		rb.readPos += n
-		rb.size -= n
-		return n, nil
+	if len(rb.waiters) > 0 {
+		rb.notifyWaiters()
 	}
+	rb.occupancyChanged()
+	rb.checkBackpressure()
+	rb.traceOp(TraceReset, 0)
+}
 
-	copy(dst, rb.buf[rb.readPos:rb.capacity])
-	copy(dst[rb.capacity-rb.readPos:], rb.buf[0:n-(rb.capacity-rb.readPos)])
+// Clear resets the buffer and zeroes the backing memory.
+func (rb *RingBuffer) Clear() {
+	rb.Reset()
+	fill(rb.buf, 0)
+}
 
-	rb.readPos = n - (rb.capacity - rb.readPos)
-	rb.size -= n
+// Read implements io.Reader: it reads up to len(dst) buffered bytes,
+// whole periods when WithPeriod is set, and returns io.EOF when nothing
+// is buffered.
+func (rb *RingBuffer) Read(dst []byte) (int, error) {
+	if rb.size == 0 && len(dst) > 0 && !rb.paused {
+		return 0, io.EOF
+	}
+	n := rb.size
+	if n > len(dst) {
+		n = len(dst)
+	}
+	if rb.period > 0 && n >= rb.period {
+		n -= n % rb.period
+	}
+
+	return rb.ReadExact(n, dst)
+}
+
+// ReadExact reads exactly n bytes into dst, or fails without consuming
+// anything. It is the Read(n, dst) of earlier versions; callers migrate
+// with
+//
+//	gofmt -r 'a.Read(b, c) -> a.ReadExact(b, c)' -w .
+func (rb *RingBuffer) ReadExact(n int, dst []byte) (int, error) {
+	if rb.paused {
+		return 0, ErrPaused
+	}
+	if n < 0 || rb.size < n {
+		return 0, rb.violation(errors.New(fmt.Sprintf("invalid n. sz: %d, n: %d", rb.size, n)))
+	}
+	if err := rb.checkPeriod(n); err != nil {
+		return 0, err
+	}
+	if len(dst) < n {
+		return 0, rb.violation(errors.New(fmt.Sprintf("dst too small. len: %d, n: %d", len(dst), n)))
+	}
+	n, err := rb.injectFault(faultRead, n)
+	if err != nil {
+		return 0, err
+	}
+	rb.copyOut(dst, 0, n)
+	rb.advanceRead(n)
+	rb.traceOp(TraceRead, n)
 
 	return n, nil
 }
 
 func (rb *RingBuffer) Write(data []byte) (int, error) {
+	if err := rb.checkPeriod(len(data)); err != nil {
+		return 0, err
+	}
+	rb.Expire()
+	skipped := 0
+	if rb.overwrite && len(data) > rb.capacity-rb.size {
+		skipped = rb.overrun(len(data))
+		data = data[skipped:]
+	}
 	szData := len(data)
-	if szData > rb.Capacity()-rb.Size() {
-		errMsg := fmt.Sprintf("data len exceed capacity. %d > %d", szData, rb.Capacity()-rb.Size())
-		return 0, errors.New(errMsg)
+	if szData > rb.capacity-rb.size {
+		errMsg := fmt.Sprintf("data len exceed capacity. %d > %d", szData, rb.capacity-rb.size)
+		return 0, rb.violation(errors.New(errMsg))
+	}
+	szData, err := rb.injectFault(faultWrite, szData)
+	if err != nil {
+		return 0, err
+	}
+	s1, s2 := rb.writeSegments(szData)
+	copy(s1, data)
+	copy(s2, data[len(s1):])
+	rb.transformWritten(s1, s2)
+	rb.advanceWrite(szData)
+	rb.traceOp(TraceWrite, skipped+szData)
+	if szData < len(data) {
+		return skipped + szData, io.ErrShortWrite
 	}
 
-	szToEnd := rb.writePos + szData
-	if szToEnd <= rb.Capacity() {
-		copy(rb.buf[rb.writePos:], data)
-		rb.writePos += len(data)
-	} else {
-		sz1 := rb.Capacity() - rb.writePos
-		copy(rb.buf[rb.writePos:], data[:sz1])
-		copy(rb.buf[0:], data[sz1:])
-		rb.writePos = szData - sz1
+	return skipped + szData, nil
+}
+
+// overrun discards the oldest data so that a write of n bytes fits. When
+// n exceeds the capacity, the head of the write itself is lost as well;
+// overrun returns how many leading bytes of the write to skip. Lost bytes
+// count as written and consumed so sequences keep matching the stream.
+func (rb *RingBuffer) overrun(n int) int {
+	skipped := 0
+	if n > rb.capacity {
+		skipped = n - rb.capacity
+		n = rb.capacity
+	}
+	drop := n - (rb.capacity - rb.size)
+	rb.advanceRead(drop)
+	if skipped > 0 {
+		rb.written += uint64(skipped)
+		rb.consumed += uint64(skipped)
+		if len(rb.waiters) > 0 {
+			rb.notifyWaiters()
+		}
 	}
-	rb.size += szData
 
-	return szData, nil
+	if rb.latest {
+		return skipped
+	}
+	lost := uint64(drop + skipped)
+	rb.lost += lost
+	if rb.onOverrun != nil {
+		rb.onOverrun(lost)
+	}
+	if rb.hooks != nil {
+		rb.hooks.OnOverrun(lost)
+	}
+
+	return skipped
+}
+
+// WriteAvailable writes as much of data as currently fits and returns the
+// number of bytes written.
+func (rb *RingBuffer) WriteAvailable(data []byte) int {
+	if free := rb.capacity - rb.size; len(data) > free {
+		data = data[:free]
+	}
+	if rb.period > 0 {
+		data = data[:len(data)-len(data)%rb.period]
+	}
+	n, _ := rb.Write(data)
+
+	return n
+}
+
+func (rb *RingBuffer) Fill(b byte, n int) (int, error) {
+	if n < 0 || n > rb.capacity-rb.size {
+		errMsg := fmt.Sprintf("data len exceed capacity. %d > %d", n, rb.capacity-rb.size)
+		return 0, rb.violation(errors.New(errMsg))
+	}
+	if err := rb.checkPeriod(n); err != nil {
+		return 0, err
+	}
+	s1, s2 := rb.writeSegments(n)
+	fill(s1, b)
+	fill(s2, b)
+	rb.transformWritten(s1, s2)
+	rb.advanceWrite(n)
+	rb.traceOp(TraceWrite, n)
+
+	return n, nil
+}
+
+func (rb *RingBuffer) WriteZeros(n int) (int, error) {
+	return rb.Fill(0, n)
+}
+
+func fill(p []byte, b byte) {
+	if len(p) == 0 {
+		return
+	}
+	// doubling copy lets memmove do the work for large fills
+	p[0] = b
+	for i := 1; i < len(p); i *= 2 {
+		copy(p[i:], p[:i])
+	}
+}
+
+// violation reports a contract violation according to the strict policy.
+func (rb *RingBuffer) violation(err error) error {
+	if rb.strict {
+		panic(err)
+	}
+
+	return err
+}
+
+func (rb *RingBuffer) advanceRead(n int) {
+	rb.copyCheck()
+	rb.readPos += n
+	if rb.readPos >= rb.capacity {
+		rb.readPos -= rb.capacity
+	}
+	rb.size -= n
+	rb.consumed += uint64(n)
+	rb.setUnread(rb.unread + n)
+	if rb.chunked {
+		rb.popChunks()
+	}
+	if rb.lat != nil {
+		rb.lat.consumed(rb.consumed, rb.hooks)
+	}
+	if rb.rate != nil {
+		rb.rate.consumed(n)
+	}
+	if rb.occupancy != nil {
+		rb.sampleOccupancy()
+	}
+	if rb.hooks != nil {
+		rb.hooks.OnRead(n, rb.size)
+	}
+	if len(rb.waiters) > 0 {
+		rb.notifyWaiters()
+	}
+	rb.checkBackpressure()
+}
+
+func (rb *RingBuffer) advanceWrite(n int) {
+	rb.copyCheck()
+	rb.writePos += n
+	if rb.writePos >= rb.capacity {
+		rb.writePos -= rb.capacity
+	}
+	rb.size += n
+	rb.written += uint64(n)
+	rb.setUnread(rb.unread)
+	if rb.chunked && n > 0 {
+		rb.chunkEnds = append(rb.chunkEnds, chunkEntry{end: rb.written, meta: rb.chunkMeta})
+	}
+	if rb.sizer != nil {
+		rb.sizer.observe(rb.size)
+	}
+	if rb.lat != nil && n > 0 {
+		rb.lat.wrote(rb.written)
+	}
+	if rb.rate != nil {
+		rb.rate.wrote(n)
+	}
+	if rb.occupancy != nil {
+		rb.sampleOccupancy()
+	}
+	if rb.ttl != nil && n > 0 {
+		rb.ttl.stamps = append(rb.ttl.stamps, writeStamp{end: rb.written, at: rb.ttl.clock.Now()})
+	}
+	if rb.hooks != nil {
+		rb.hooks.OnWrite(n, rb.size)
+	}
+	rb.checkBackpressure()
+}
+
+// ReadLatest copies the newest min(len(dst), Size()) bytes into dst
+// without consuming anything.
+func (rb *RingBuffer) ReadLatest(dst []byte) int {
+	n := len(dst)
+	if n > rb.size {
+		n = rb.size
+	}
+	rb.copyOut(dst, rb.size-n, n)
+
+	return n
+}
+
+func (rb *RingBuffer) Bytes() []byte {
+	out := make([]byte, rb.size)
+	rb.copyOut(out, 0, rb.size)
+
+	return out
+}
+
+const previewLen = 16
+
+func (rb *RingBuffer) String() string {
+	return fmt.Sprintf("RingBuffer{capacity: %d, size: %d, readPos: %d, writePos: %d}",
+		rb.capacity, rb.size, rb.readPos, rb.writePos)
+}
+
+// Format implements fmt.Formatter. %+v adds a hex preview of at most
+// previewLen bytes from the head and tail of the readable data.
+func (rb *RingBuffer) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v', 's':
+		if verb == 'v' && f.Flag('+') {
+			fmt.Fprintf(f, "RingBuffer{capacity: %d, size: %d, readPos: %d, writePos: %d, head: %s, tail: %s}",
+				rb.capacity, rb.size, rb.readPos, rb.writePos, rb.preview(0), rb.preview(rb.size-previewLen))
+			return
+		}
+		fmt.Fprint(f, rb.String())
+	default:
+		fmt.Fprintf(f, "%%!%c(RingBuffer=%s)", verb, rb.String())
+	}
+}
+
+func (rb *RingBuffer) preview(off int) string {
+	if off < 0 {
+		off = 0
+	}
+	n := rb.size - off
+	if n > previewLen {
+		n = previewLen
+	}
+	s1, s2 := rb.readSegments(off, n)
+
+	return fmt.Sprintf("[% x]", append(append([]byte{}, s1...), s2...))
+}
+
+func (rb *RingBuffer) Clone() *RingBuffer {
+	c := &RingBuffer{ringState: rb.ringState}
+	c.addr = c
+	c.buf = make([]byte, len(rb.buf))
+	copy(c.buf, rb.buf)
+	c.numa = nil
+	c.waiters = nil
+	c.trace = nil
+	if rb.sizer != nil {
+		sizer := *rb.sizer
+		sizer.samples = append([]int(nil), rb.sizer.samples...)
+		c.sizer = &sizer
+	}
+	if rb.lat != nil {
+		lat := *rb.lat
+		lat.stamps = append([]writeStamp(nil), rb.lat.stamps...)
+		c.lat = &lat
+	}
+	if rb.rate != nil {
+		rate := *rb.rate
+		c.rate = &rate
+	}
+	if rb.ttl != nil {
+		ttl := *rb.ttl
+		ttl.stamps = append([]writeStamp(nil), rb.ttl.stamps...)
+		c.ttl = &ttl
+	}
+	c.chunkEnds = append([]chunkEntry(nil), rb.chunkEnds...)
+	if rb.occupancy != nil {
+		c.occupancy = append([]uint64(nil), rb.occupancy...)
+	}
+	c.bp = nil
+	if rb.enc != nil {
+		c.enc = rb.enc.clone()
+		c.writeXform = replaceTransform(rb.writeXform, rb.enc, c.enc)
+		c.readXform = replaceTransform(rb.readXform, rb.enc, c.enc)
+		c.rekeyHeld()
+	}
+
+	return c
+}
+
+func (rb *RingBuffer) HasPrefix(p []byte) bool {
+	if len(p) > rb.size {
+		return false
+	}
+	if len(rb.readXform) > 0 {
+		// compare what reads return, not the stored bytes
+		var tmp [64]byte
+		for off := 0; off < len(p); off += len(tmp) {
+			n := len(p) - off
+			if n > len(tmp) {
+				n = len(tmp)
+			}
+			rb.copyOut(tmp[:n], off, n)
+			if !bytes.Equal(tmp[:n], p[off:off+n]) {
+				return false
+			}
+		}
+		return true
+	}
+	s1, s2 := rb.readSegments(0, len(p))
+
+	return bytes.Equal(s1, p[:len(s1)]) && bytes.Equal(s2, p[len(s1):])
+}
+
+func (rb *RingBuffer) Equal(p []byte) bool {
+	return len(p) == rb.size && rb.HasPrefix(p)
+}
+
+func (rb *RingBuffer) DropOldest(n int) error {
+	if rb.paused {
+		return ErrPaused
+	}
+	if n < 0 || rb.size < n {
+		return rb.violation(errors.New(fmt.Sprintf("invalid n. sz: %d, n: %d", rb.size, n)))
+	}
+	if err := rb.checkPeriod(n); err != nil {
+		return err
+	}
+	rb.advanceRead(n)
+	rb.traceOp(TraceDrop, n)
+
+	return nil
+}
+
+// TruncateNewest retracts the last n written bytes, as if they had never
+// been written.
+func (rb *RingBuffer) TruncateNewest(n int) error {
+	if n < 0 || rb.size < n {
+		return rb.violation(errors.New(fmt.Sprintf("invalid n. sz: %d, n: %d", rb.size, n)))
+	}
+	if err := rb.checkPeriod(n); err != nil {
+		return err
+	}
+	rb.writePos -= n
+	if rb.writePos < 0 {
+		rb.writePos += rb.capacity
+	}
+	rb.size -= n
+	rb.written -= uint64(n)
+	if rb.enc != nil {
+		rb.rekeyHeld()
+	}
+	if rb.chunked {
+		rb.retractChunks()
+	}
+	if rb.lat != nil {
+		rb.lat.retracted(rb.written, rb.size > 0)
+	}
+	if rb.ttl != nil {
+		rb.ttl.stamps = retractStamps(rb.ttl.stamps, rb.written, rb.size > 0)
+	}
+	rb.occupancyChanged()
+	rb.checkBackpressure()
+	rb.traceOp(TraceTruncate, n)
+
+	return nil
+}
+
+// Unread pushes back the last n consumed bytes. Consumed bytes stay
+// recoverable until a later write reuses their space.
+func (rb *RingBuffer) Unread(n int) error {
+	if n < 0 || rb.unread < n {
+		return rb.violation(errors.New(fmt.Sprintf("invalid n. unread: %d, n: %d", rb.unread, n)))
+	}
+	if err := rb.checkPeriod(n); err != nil {
+		return err
+	}
+	rb.readPos -= n
+	if rb.readPos < 0 {
+		rb.readPos += rb.capacity
+	}
+	rb.size += n
+	rb.consumed -= uint64(n)
+	rb.unread -= n
+	if rb.chunked {
+		rb.unpopChunks()
+	}
+	rb.occupancyChanged()
+	rb.checkBackpressure()
+	rb.traceOp(TraceUnread, n)
+
+	return nil
+}
+
+func (rb *RingBuffer) UnreadByte() error {
+	return rb.Unread(1)
+}
+
+// setUnread records how many consumed bytes in front of readPos are still
+// intact, which is bounded by the free space writes have not reused.
+func (rb *RingBuffer) setUnread(n int) {
+	if free := rb.capacity - rb.size; n > free {
+		n = free
+	}
+	rb.unread = n
 }
@@ -1,6 +1,10 @@
 package ringbuffer
 
 import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -76,7 +80,7 @@ func Test_4(t *testing.T) {
 	assert.Equal(t, 3, rb.Size())
 
 	out1 := make([]byte, 3)
-	nr, err := rb.Read(2, out1)
+	nr, err := rb.ReadExact(2, out1)
 	assert.Nil(t, err)
 	assert.Equal(t, 2, nr)
 	assert.Equal(t, 1, rb.Size())
@@ -84,10 +88,10 @@ func Test_4(t *testing.T) {
 	assert.Equal(t, []byte{1, 2}, out1[:2])
 	assert.Equal(t, 3, rb.writePos)
 
-	nr, err = rb.Read(2, out1)
+	nr, err = rb.ReadExact(2, out1)
 	assert.NotNil(t, err)
 
-	nr, err = rb.Read(1, out1)
+	nr, err = rb.ReadExact(1, out1)
 	assert.Nil(t, err)
 	assert.Equal(t, 1, nr)
 	assert.Equal(t, 0, rb.Size())
@@ -108,7 +112,7 @@ func Test_5(t *testing.T) {
 	assert.Equal(t, 3, rb.writePos)
 
 	out1 := make([]byte, 3)
-	nr, err := rb.Read(2, out1)
+	nr, err := rb.ReadExact(2, out1)
 	assert.Nil(t, err)
 	assert.Equal(t, 2, nr)
 	assert.Equal(t, 1, rb.Size())
@@ -126,9 +130,596 @@ func Test_5(t *testing.T) {
 
 	assert.Equal(t, 2, rb.readPos)
 	out2 := make([]byte, 4)
-	nr, err = rb.Read(4, out2)
+	nr, err = rb.ReadExact(4, out2)
 	assert.Nil(t, err)
 	assert.Equal(t, 4, nr)
 	assert.Equal(t, 0, rb.Size())
 	assert.Equal(t, []byte{3, 4, 5, 6}, out2[:4])
 }
+
+func Test_ReadIOReader(t *testing.T) {
+
+	rb := NewRingBuffer(8)
+	var _ io.Reader = rb
+
+	out := make([]byte, 4)
+	n, err := rb.Read(out)
+	assert.Equal(t, 0, n)
+	assert.Equal(t, io.EOF, err)
+	n, err = rb.Read(nil)
+	assert.Equal(t, 0, n)
+	assert.Nil(t, err)
+
+	rb.Write([]byte("abcdef"))
+	n, err = rb.Read(out)
+	assert.Nil(t, err)
+	assert.Equal(t, "abcd", string(out[:n]))
+	n, err = rb.Read(out)
+	assert.Nil(t, err)
+	assert.Equal(t, "ef", string(out[:n]))
+
+	rb.Write([]byte("ghij"))
+	rb.Pause()
+	_, err = rb.Read(out)
+	assert.Equal(t, ErrPaused, err)
+	rb.Resume()
+
+	rest, err := io.ReadAll(rb)
+	assert.Nil(t, err)
+	assert.Equal(t, "ghij", string(rest))
+
+	periodic := NewRingBuffer(8, WithPeriod(2))
+	periodic.Write([]byte("abcdef"))
+	n, err = periodic.Read(out[:3])
+	assert.Nil(t, err)
+	assert.Equal(t, "ab", string(out[:n]))
+	_, err = periodic.Read(out[:1])
+	assert.ErrorIs(t, err, ErrNotPeriodAligned)
+}
+
+func Test_WrapAround(t *testing.T) {
+
+	capacity := 7
+
+	rb := NewRingBuffer(capacity)
+
+	out := make([]byte, 5)
+	var next, expect byte
+	for i := 0; i < 50; i++ {
+		data := []byte{next, next + 1, next + 2, next + 3, next + 4}
+		next += 5
+
+		nw, err := rb.Write(data)
+		assert.Nil(t, err)
+		assert.Equal(t, 5, nw)
+
+		nr, err := rb.ReadExact(5, out)
+		assert.Nil(t, err)
+		assert.Equal(t, 5, nr)
+		assert.Equal(t, []byte{expect, expect + 1, expect + 2, expect + 3, expect + 4}, out)
+		expect += 5
+	}
+	assert.Equal(t, 0, rb.Size())
+}
+
+func benchmarkRingBuffer(b *testing.B, capacity, chunk int) {
+	rb := NewRingBuffer(capacity)
+	data := make([]byte, chunk)
+	out := make([]byte, chunk)
+
+	b.SetBytes(int64(chunk))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rb.Write(data)
+		rb.ReadExact(chunk, out)
+	}
+}
+
+func BenchmarkWrite(b *testing.B) {
+	rb := NewRingBuffer(4096)
+	data := make([]byte, 1000)
+
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if rb.AvailableWriteSize() < len(data) {
+			rb.Reset()
+		}
+		rb.Write(data)
+	}
+}
+
+func BenchmarkRead(b *testing.B) {
+	rb := NewRingBuffer(4096)
+	data := make([]byte, 4096)
+	out := make([]byte, 1000)
+
+	b.SetBytes(int64(len(out)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if rb.Size() < len(out) {
+			rb.Reset()
+			rb.Write(data)
+		}
+		rb.ReadExact(len(out), out)
+	}
+}
+
+func BenchmarkThroughput(b *testing.B) {
+	b.Run("64", func(b *testing.B) { benchmarkRingBuffer(b, 4096, 64) })
+	b.Run("1000", func(b *testing.B) { benchmarkRingBuffer(b, 4096, 1000) })
+	b.Run("4096", func(b *testing.B) { benchmarkRingBuffer(b, 65536, 4096) })
+}
+
+func Test_Bytes(t *testing.T) {
+
+	rb := NewRingBuffer(5)
+	assert.Equal(t, []byte{}, rb.Bytes())
+
+	rb.Write([]byte{1, 2, 3, 4})
+	out := make([]byte, 3)
+	rb.ReadExact(3, out)
+	rb.Write([]byte{5, 6, 7})
+
+	b := rb.Bytes()
+	assert.Equal(t, []byte{4, 5, 6, 7}, b)
+	assert.Equal(t, 4, rb.Size())
+
+	b[0] = 42
+	assert.Equal(t, []byte{4, 5, 6, 7}, rb.Bytes())
+}
+
+func Test_String(t *testing.T) {
+
+	rb := NewRingBuffer(5)
+	rb.Write([]byte{1, 2, 3})
+
+	assert.Equal(t, "RingBuffer{capacity: 5, size: 3, readPos: 0, writePos: 3}", rb.String())
+	assert.Equal(t, rb.String(), fmt.Sprintf("%v", rb))
+	assert.Equal(t, rb.String(), fmt.Sprintf("%s", rb))
+	assert.Equal(t, "RingBuffer{capacity: 5, size: 3, readPos: 0, writePos: 3, head: [01 02 03], tail: [01 02 03]}",
+		fmt.Sprintf("%+v", rb))
+
+	big := NewRingBuffer(1 << 20)
+	big.Write(make([]byte, 1<<20))
+	assert.Less(t, len(fmt.Sprintf("%+v", big)), 256)
+}
+
+func Test_Clone(t *testing.T) {
+
+	rb := NewRingBuffer(5)
+	rb.Write([]byte{1, 2, 3, 4})
+	out := make([]byte, 2)
+	rb.ReadExact(2, out)
+	rb.Write([]byte{5, 6})
+
+	c := rb.Clone()
+	assert.Equal(t, rb.Capacity(), c.Capacity())
+	assert.Equal(t, rb.Size(), c.Size())
+	assert.Equal(t, rb.Bytes(), c.Bytes())
+
+	rb.ReadExact(2, out)
+	c.Write([]byte{7})
+	assert.Equal(t, []byte{5, 6}, rb.Bytes())
+	assert.Equal(t, []byte{3, 4, 5, 6, 7}, c.Bytes())
+}
+
+func Test_HasPrefix(t *testing.T) {
+
+	rb := NewRingBuffer(5)
+	assert.True(t, rb.HasPrefix(nil))
+	assert.True(t, rb.Equal([]byte{}))
+
+	rb.Write([]byte{1, 2, 3, 4})
+	out := make([]byte, 3)
+	rb.ReadExact(3, out)
+	rb.Write([]byte{5, 6, 7})
+
+	assert.True(t, rb.HasPrefix([]byte{4, 5}))
+	assert.True(t, rb.HasPrefix([]byte{4, 5, 6, 7}))
+	assert.False(t, rb.HasPrefix([]byte{4, 5, 6, 7, 8}))
+	assert.False(t, rb.HasPrefix([]byte{4, 5, 7}))
+
+	assert.True(t, rb.Equal([]byte{4, 5, 6, 7}))
+	assert.False(t, rb.Equal([]byte{4, 5, 6}))
+	assert.Equal(t, 4, rb.Size())
+}
+
+func Test_NewChecked(t *testing.T) {
+
+	rb, err := NewChecked(1000)
+	assert.Nil(t, err)
+	assert.Equal(t, 1000, rb.Capacity())
+
+	for _, capacity := range []int{0, -1, MaxCapacity + 1} {
+		rb, err = NewChecked(capacity)
+		assert.Nil(t, rb)
+		assert.ErrorIs(t, err, ErrInvalidCapacity)
+	}
+}
+
+func Test_WithBuffer(t *testing.T) {
+
+	var storage [8]byte
+	rb := NewRingBuffer(6, WithBuffer(storage[:]))
+	rb.Write([]byte{1, 2, 3})
+	assert.Equal(t, []byte{1, 2, 3, 0, 0, 0, 0, 0}, storage[:])
+	assert.Equal(t, 6, rb.AvailableWriteSize()+rb.Size())
+
+	checked, err := NewChecked(6, WithBuffer(storage[:]))
+	assert.Nil(t, err)
+	assert.Equal(t, 6, checked.Capacity())
+
+	_, err = NewChecked(10, WithBuffer(storage[:]))
+	assert.ErrorIs(t, err, ErrInvalidCapacity)
+
+	short := NewRingBuffer(10, WithBuffer(storage[:]))
+	short.Write(make([]byte, 10))
+	assert.Equal(t, 10, short.Size())
+}
+
+func Test_DropOldest(t *testing.T) {
+
+	rb := NewRingBuffer(5)
+	rb.Write([]byte{1, 2, 3, 4})
+
+	assert.Nil(t, rb.DropOldest(3))
+	assert.Equal(t, []byte{4}, rb.Bytes())
+
+	rb.Write([]byte{5, 6, 7})
+	assert.Nil(t, rb.DropOldest(2))
+	assert.Equal(t, []byte{6, 7}, rb.Bytes())
+
+	assert.NotNil(t, rb.DropOldest(3))
+	assert.NotNil(t, rb.DropOldest(-1))
+	assert.Equal(t, 2, rb.Size())
+}
+
+func Test_TruncateNewest(t *testing.T) {
+
+	rb := NewRingBuffer(5)
+	rb.Write([]byte{1, 2, 3, 4})
+	rb.DropOldest(3)
+	rb.Write([]byte{5, 6, 7})
+
+	assert.Nil(t, rb.TruncateNewest(2))
+	assert.Equal(t, []byte{4, 5}, rb.Bytes())
+
+	rb.Write([]byte{8, 9})
+	assert.Equal(t, []byte{4, 5, 8, 9}, rb.Bytes())
+
+	assert.NotNil(t, rb.TruncateNewest(5))
+	assert.Nil(t, rb.TruncateNewest(4))
+	assert.Equal(t, 0, rb.Size())
+}
+
+func Test_Unread(t *testing.T) {
+
+	rb := NewRingBuffer(5)
+	assert.NotNil(t, rb.UnreadByte())
+
+	rb.Write([]byte{1, 2, 3, 4})
+	out := make([]byte, 3)
+	rb.ReadExact(3, out)
+
+	assert.Nil(t, rb.UnreadByte())
+	assert.Equal(t, []byte{3, 4}, rb.Bytes())
+	assert.Nil(t, rb.Unread(2))
+	assert.Equal(t, []byte{1, 2, 3, 4}, rb.Bytes())
+	assert.NotNil(t, rb.Unread(1))
+
+	rb.ReadExact(3, out)
+	rb.Write([]byte{5, 6})
+	// 5, 6 landed at positions 4 and 0, overwriting byte 1
+	assert.NotNil(t, rb.Unread(3))
+	assert.Nil(t, rb.Unread(2))
+	assert.Equal(t, []byte{2, 3, 4, 5, 6}, rb.Bytes())
+
+	rb.DropOldest(4)
+	rb.Reset()
+	assert.NotNil(t, rb.UnreadByte())
+}
+
+func Test_Fill(t *testing.T) {
+
+	rb := NewRingBuffer(5)
+	rb.Write([]byte{1, 2, 3, 4})
+	rb.DropOldest(3)
+
+	nw, err := rb.Fill(9, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, nw)
+	assert.Equal(t, []byte{4, 9, 9, 9}, rb.Bytes())
+
+	nw, err = rb.WriteZeros(2)
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, nw)
+
+	nw, err = rb.WriteZeros(1)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{4, 9, 9, 9, 0}, rb.Bytes())
+
+	big := NewRingBuffer(1000)
+	big.Fill(7, 999)
+	assert.Equal(t, bytes.Repeat([]byte{7}, 999), big.Bytes())
+}
+
+func Test_WriteAvailable(t *testing.T) {
+
+	rb := NewRingBuffer(5)
+	rb.Write([]byte{1, 2})
+
+	assert.Equal(t, 3, rb.WriteAvailable([]byte{3, 4, 5, 6, 7}))
+	assert.Equal(t, []byte{1, 2, 3, 4, 5}, rb.Bytes())
+	assert.Equal(t, 0, rb.WriteAvailable([]byte{8}))
+
+	rb.DropOldest(4)
+	assert.Equal(t, 2, rb.WriteAvailable([]byte{6, 7}))
+	assert.Equal(t, []byte{5, 6, 7}, rb.Bytes())
+}
+
+func Test_AvailableContiguous(t *testing.T) {
+
+	rb := NewRingBuffer(8)
+	assert.Equal(t, 8, rb.AvailableWriteContiguous())
+	assert.Equal(t, 0, rb.AvailableReadContiguous())
+
+	rb.Write([]byte{1, 2, 3, 4, 5, 6})
+	rb.DropOldest(4)
+	// free space wraps: 2 bytes at the end, 4 at the start
+	assert.Equal(t, 6, rb.AvailableWriteSize())
+	assert.Equal(t, 2, rb.AvailableWriteContiguous())
+	assert.Equal(t, 2, rb.AvailableReadContiguous())
+
+	rb.Write([]byte{7, 8, 9})
+	// data wraps: 4 bytes before the end, 1 after
+	assert.Equal(t, 4, rb.AvailableReadContiguous())
+	assert.Equal(t, 3, rb.AvailableWriteContiguous())
+	s1, _ := rb.readSegments(0, rb.AvailableReadContiguous())
+	assert.Equal(t, []byte{5, 6, 7, 8}, s1)
+
+	rb.Write([]byte{10, 11, 12})
+	assert.True(t, rb.IsFull())
+	assert.Equal(t, 0, rb.AvailableWriteContiguous())
+	assert.Equal(t, 4, rb.AvailableReadContiguous())
+
+	assert.Equal(t, 0, rb.Writer().AvailableWriteContiguous())
+	assert.Equal(t, 4, rb.Reader().AvailableReadContiguous())
+}
+
+func Test_Sequence(t *testing.T) {
+
+	rb := NewRingBuffer(5)
+	out := make([]byte, 5)
+
+	rb.Write([]byte{1, 2, 3, 4})
+	rb.ReadExact(3, out)
+	assert.Equal(t, uint64(4), rb.WrittenSequence())
+	assert.Equal(t, uint64(3), rb.ConsumedSequence())
+
+	rb.Write([]byte{5, 6, 7})
+	rb.DropOldest(2)
+	assert.Equal(t, uint64(7), rb.WrittenSequence())
+	assert.Equal(t, uint64(5), rb.ConsumedSequence())
+
+	rb.Unread(1)
+	rb.TruncateNewest(1)
+	assert.Equal(t, uint64(6), rb.WrittenSequence())
+	assert.Equal(t, uint64(4), rb.ConsumedSequence())
+	assert.Equal(t, rb.Size(), int(rb.WrittenSequence()-rb.ConsumedSequence()))
+
+	rb.Reset()
+	assert.Equal(t, uint64(0), rb.WrittenSequence())
+	assert.Equal(t, uint64(0), rb.ConsumedSequence())
+}
+
+func isClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func Test_NotifyConsumed(t *testing.T) {
+
+	rb := NewRingBuffer(5)
+	out := make([]byte, 5)
+
+	assert.True(t, isClosed(rb.NotifyConsumed(0)))
+
+	rb.Write([]byte{1, 2, 3})
+	first := rb.NotifyConsumed(rb.WrittenSequence())
+	rb.Write([]byte{4, 5})
+	second := rb.NotifyConsumed(rb.WrittenSequence())
+
+	rb.ReadExact(2, out)
+	assert.False(t, isClosed(first))
+	rb.ReadExact(1, out)
+	assert.True(t, isClosed(first))
+	assert.False(t, isClosed(second))
+
+	rb.DropOldest(2)
+	assert.True(t, isClosed(second))
+
+	rb.Write([]byte{6})
+	third := rb.NotifyConsumed(rb.WrittenSequence())
+	assert.Equal(t, 1, len(rb.waiters))
+	c := rb.Clone()
+	c.ReadExact(1, out)
+	assert.False(t, isClosed(third))
+
+	rb.Reset()
+	assert.True(t, isClosed(third))
+	assert.Equal(t, 0, len(rb.waiters))
+}
+
+func Test_Pause(t *testing.T) {
+
+	rb := NewRingBuffer(5)
+	out := make([]byte, 5)
+	rb.Write([]byte{1, 2})
+
+	rb.Pause()
+	assert.True(t, rb.IsPaused())
+
+	nr, err := rb.ReadExact(1, out)
+	assert.ErrorIs(t, err, ErrPaused)
+	assert.Equal(t, 0, nr)
+	assert.ErrorIs(t, rb.DropOldest(1), ErrPaused)
+
+	nw, err := rb.Write([]byte{3, 4})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, nw)
+
+	rb.Resume()
+	assert.False(t, rb.IsPaused())
+	nr, err = rb.ReadExact(4, out)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{1, 2, 3, 4}, out[:nr])
+}
+
+func Test_ResetKeepStats(t *testing.T) {
+
+	rb := NewRingBuffer(5)
+	out := make([]byte, 5)
+	rb.Write([]byte{1, 2, 3})
+	rb.ReadExact(1, out)
+	done := rb.NotifyConsumed(rb.WrittenSequence())
+
+	rb.ResetKeepStats()
+	assert.Equal(t, 0, rb.Size())
+	assert.Equal(t, uint64(3), rb.WrittenSequence())
+	assert.Equal(t, uint64(3), rb.ConsumedSequence())
+	assert.True(t, isClosed(done))
+	assert.NotNil(t, rb.UnreadByte())
+
+	rb.Write([]byte{4})
+	assert.Equal(t, []byte{4}, rb.Bytes())
+	assert.Equal(t, uint64(4), rb.WrittenSequence())
+}
+
+func Test_Clear(t *testing.T) {
+
+	rb := NewRingBuffer(5)
+	rb.Write([]byte{1, 2, 3})
+
+	rb.Clear()
+	assert.Equal(t, 0, rb.Size())
+	assert.Equal(t, uint64(0), rb.WrittenSequence())
+	assert.Equal(t, []byte{0, 0, 0, 0, 0}, rb.buf)
+}
+
+func Test_SequenceWraparound(t *testing.T) {
+
+	rb := NewRingBuffer(5)
+	rb.written = math.MaxUint64 - 2
+	rb.consumed = math.MaxUint64 - 2
+	out := make([]byte, 5)
+
+	rb.Write([]byte{1, 2, 3, 4})
+	assert.Equal(t, uint64(1), rb.WrittenSequence())
+	assert.Equal(t, 4, int(rb.WrittenSequence()-rb.ConsumedSequence()))
+
+	done := rb.NotifyConsumed(rb.WrittenSequence())
+	assert.False(t, isClosed(done))
+	assert.True(t, isClosed(rb.NotifyConsumed(math.MaxUint64-3)))
+
+	rb.ReadExact(2, out)
+	assert.Equal(t, uint64(math.MaxUint64), rb.ConsumedSequence())
+	assert.False(t, isClosed(done))
+
+	rb.ReadExact(2, out)
+	assert.Equal(t, uint64(1), rb.ConsumedSequence())
+	assert.True(t, isClosed(done))
+
+	rb.Unread(2)
+	assert.Equal(t, uint64(math.MaxUint64), rb.ConsumedSequence())
+	assert.Equal(t, 2, int(rb.WrittenSequence()-rb.ConsumedSequence()))
+}
+
+func Test_Strict(t *testing.T) {
+
+	rb := NewRingBuffer(5)
+	out := make([]byte, 5)
+	rb.Write([]byte{1, 2, 3})
+
+	_, err := rb.ReadExact(4, out)
+	assert.NotNil(t, err)
+	_, err = rb.ReadExact(3, out[:2])
+	assert.NotNil(t, err)
+	_, err = rb.ReadExact(-1, out)
+	assert.NotNil(t, err)
+	assert.Equal(t, 3, rb.Size())
+
+	srb, err := NewChecked(5, WithStrict())
+	assert.Nil(t, err)
+	srb.Write([]byte{1, 2, 3})
+
+	assert.Panics(t, func() { srb.ReadExact(4, out) })
+	assert.Panics(t, func() { srb.ReadExact(3, nil) })
+	assert.Panics(t, func() { srb.Write([]byte{4, 5, 6}) })
+	assert.Panics(t, func() { srb.Fill(0, 3) })
+	assert.Panics(t, func() { srb.DropOldest(4) })
+	assert.Panics(t, func() { srb.TruncateNewest(4) })
+	assert.Panics(t, func() { srb.UnreadByte() })
+	assert.Equal(t, 2, srb.WriteAvailable([]byte{4, 5, 6}))
+	assert.Equal(t, []byte{1, 2, 3, 4, 5}, srb.Bytes())
+}
+
+func Test_Overwrite(t *testing.T) {
+
+	var events []uint64
+	rb := NewRingBuffer(5, WithOverwrite(), OnOverrun(func(lost uint64) {
+		events = append(events, lost)
+	}))
+
+	nw, err := rb.Write([]byte{1, 2, 3, 4})
+	assert.Nil(t, err)
+	assert.Equal(t, 4, nw)
+	assert.Equal(t, uint64(0), rb.LostBytes())
+
+	nw, err = rb.Write([]byte{5, 6, 7})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, nw)
+	assert.Equal(t, []byte{3, 4, 5, 6, 7}, rb.Bytes())
+	assert.Equal(t, uint64(2), rb.LostBytes())
+
+	nw, err = rb.Write([]byte{8, 9, 10, 11, 12, 13, 14})
+	assert.Nil(t, err)
+	assert.Equal(t, 7, nw)
+	assert.Equal(t, []byte{10, 11, 12, 13, 14}, rb.Bytes())
+	assert.Equal(t, uint64(9), rb.LostBytes())
+	assert.Equal(t, []uint64{2, 7}, events)
+
+	assert.Equal(t, uint64(14), rb.WrittenSequence())
+	assert.Equal(t, uint64(9), rb.ConsumedSequence())
+	assert.NotNil(t, rb.UnreadByte())
+}
+
+func Test_KeepLatest(t *testing.T) {
+
+	rb := NewRingBuffer(4, WithKeepLatest())
+	out := make([]byte, 3)
+
+	assert.Equal(t, 0, rb.ReadLatest(out))
+
+	rb.Write([]byte{1, 2})
+	assert.Equal(t, 2, rb.ReadLatest(out))
+	assert.Equal(t, []byte{1, 2}, out[:2])
+
+	for i := byte(3); i < 20; i++ {
+		nw, err := rb.Write([]byte{i})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, nw)
+	}
+	assert.Equal(t, 3, rb.ReadLatest(out))
+	assert.Equal(t, []byte{17, 18, 19}, out)
+	assert.Equal(t, []byte{16, 17, 18, 19}, rb.Bytes())
+	assert.Equal(t, uint64(0), rb.LostBytes())
+
+	rb.Write([]byte{20, 21, 22, 23, 24, 25})
+	assert.Equal(t, 3, rb.ReadLatest(out))
+	assert.Equal(t, []byte{23, 24, 25}, out)
+}
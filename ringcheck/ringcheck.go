@@ -0,0 +1,245 @@
+// Package ringcheck provides a vet-style analyzer that flags common
+// misuse of the ringbuffer API in client code:
+//
+//   - calling Write and dropping both results, losing short writes;
+//   - calling ReadExact(n, dst) where dst is statically shorter than n;
+//   - using ReadIovec views after ConsumeIovec released them.
+package ringcheck
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const ringbufferPath = "github.com/drgolem/ringbuffer"
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "ringcheck",
+	Doc:      "report misuse of github.com/drgolem/ringbuffer",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	ins.Preorder([]ast.Node{(*ast.ExprStmt)(nil)}, func(n ast.Node) {
+		call, ok := n.(*ast.ExprStmt).X.(*ast.CallExpr)
+		if ok && ringMethod(pass, call) == "Write" {
+			pass.Reportf(call.Pos(), "result of Write is ignored; a short write would go unnoticed")
+		}
+	})
+
+	ins.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		if ringMethod(pass, call) != "ReadExact" || len(call.Args) != 2 {
+			return
+		}
+		want, ok := constInt(pass, call.Args[0])
+		if !ok {
+			return
+		}
+		if have, ok := staticLen(pass, call.Args[1]); ok && have < want {
+			pass.Reportf(call.Pos(), "ReadExact of %d bytes into a dst of length %d", want, have)
+		}
+	})
+
+	ins.Preorder([]ast.Node{(*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)}, func(n ast.Node) {
+		var body *ast.BlockStmt
+		switch fn := n.(type) {
+		case *ast.FuncDecl:
+			body = fn.Body
+		case *ast.FuncLit:
+			body = fn.Body
+		}
+		if body != nil {
+			checkIovec(pass, body)
+		}
+	})
+
+	return nil, nil
+}
+
+// ringMethod returns the name of the ringbuffer method call invokes, or
+// "" if it is not one.
+func ringMethod(pass *analysis.Pass, call *ast.CallExpr) string {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	fn, ok := pass.TypesInfo.Uses[sel.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != ringbufferPath {
+		return ""
+	}
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return ""
+	}
+	t := recv.Type()
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	named, ok := t.(*types.Named)
+	if !ok {
+		return ""
+	}
+	switch named.Obj().Name() {
+	case "RingBuffer", "Reader", "Writer":
+		return fn.Name()
+	}
+
+	return ""
+}
+
+func constInt(pass *analysis.Pass, e ast.Expr) (int64, bool) {
+	tv, ok := pass.TypesInfo.Types[e]
+	if !ok || tv.Value == nil {
+		return 0, false
+	}
+
+	return constant.Int64Val(constant.ToInt(tv.Value))
+}
+
+// staticLen returns the length of e when it is a make call or a slice
+// of an array with constant bounds, or a variable defined once from one.
+func staticLen(pass *analysis.Pass, e ast.Expr) (int64, bool) {
+	switch e := e.(type) {
+	case *ast.ParenExpr:
+		return staticLen(pass, e.X)
+	case *ast.CallExpr:
+		if id, ok := e.Fun.(*ast.Ident); ok && id.Name == "make" && len(e.Args) >= 2 {
+			if _, ok := pass.TypesInfo.Uses[id].(*types.Builtin); ok {
+				return constInt(pass, e.Args[1])
+			}
+		}
+	case *ast.SliceExpr:
+		if arr, ok := pass.TypesInfo.TypeOf(e.X).Underlying().(*types.Array); ok && e.High == nil {
+			low := int64(0)
+			if e.Low != nil {
+				l, ok := constInt(pass, e.Low)
+				if !ok {
+					return 0, false
+				}
+				low = l
+			}
+			return arr.Len() - low, true
+		}
+		if e.High != nil {
+			high, ok := constInt(pass, e.High)
+			if !ok {
+				return 0, false
+			}
+			low := int64(0)
+			if e.Low != nil {
+				if low, ok = constInt(pass, e.Low); !ok {
+					return 0, false
+				}
+			}
+			return high - low, true
+		}
+	case *ast.Ident:
+		if v, ok := pass.TypesInfo.Uses[e].(*types.Var); ok {
+			if init := singleDefinition(pass, v); init != nil {
+				return staticLen(pass, init)
+			}
+		}
+	}
+
+	return 0, false
+}
+
+// singleDefinition returns the expression v was defined with when v is
+// a local assigned exactly once, by its := definition.
+func singleDefinition(pass *analysis.Pass, v *types.Var) ast.Expr {
+	var init ast.Expr
+	assigns := 0
+	for _, f := range pass.Files {
+		if f.Pos() > v.Pos() || v.Pos() > f.End() {
+			continue
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			as, ok := n.(*ast.AssignStmt)
+			if !ok {
+				return true
+			}
+			for i, lhs := range as.Lhs {
+				id, ok := lhs.(*ast.Ident)
+				if !ok {
+					continue
+				}
+				if pass.TypesInfo.Defs[id] == v || pass.TypesInfo.Uses[id] == v {
+					assigns++
+					if as.Tok == token.DEFINE && len(as.Lhs) == len(as.Rhs) {
+						init = as.Rhs[i]
+					}
+				}
+			}
+			return true
+		})
+	}
+	if assigns != 1 {
+		return nil
+	}
+
+	return init
+}
+
+// checkIovec reports uses of variables holding ReadIovec views that come
+// after a ConsumeIovec call on the same buffer within body.
+func checkIovec(pass *analysis.Pass, body *ast.BlockStmt) {
+	views := map[*types.Var]string{}
+	consumed := map[string]token.Pos{}
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		if _, ok := n.(*ast.FuncLit); ok {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if len(n.Lhs) != 1 || len(n.Rhs) != 1 {
+				return true
+			}
+			call, ok := n.Rhs[0].(*ast.CallExpr)
+			if !ok || ringMethod(pass, call) != "ReadIovec" {
+				return true
+			}
+			if id, ok := n.Lhs[0].(*ast.Ident); ok {
+				if v, ok := pass.TypesInfo.ObjectOf(id).(*types.Var); ok {
+					views[v] = receiver(call)
+					delete(consumed, receiver(call))
+				}
+			}
+		case *ast.CallExpr:
+			if ringMethod(pass, n) == "ConsumeIovec" {
+				if _, ok := consumed[receiver(n)]; !ok {
+					consumed[receiver(n)] = n.End()
+				}
+			}
+		case *ast.Ident:
+			v, ok := pass.TypesInfo.Uses[n].(*types.Var)
+			if !ok {
+				return true
+			}
+			rb, ok := views[v]
+			if !ok {
+				return true
+			}
+			if pos, ok := consumed[rb]; ok && n.Pos() > pos {
+				pass.Reportf(n.Pos(), "%s holds ReadIovec views released by ConsumeIovec", n.Name)
+			}
+		}
+		return true
+	})
+}
+
+// receiver renders the receiver expression of a method call so calls on
+// the same buffer variable compare equal.
+func receiver(call *ast.CallExpr) string {
+	return types.ExprString(call.Fun.(*ast.SelectorExpr).X)
+}
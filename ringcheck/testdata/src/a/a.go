@@ -0,0 +1,33 @@
+package a
+
+import "github.com/drgolem/ringbuffer"
+
+func writes(rb *ringbuffer.RingBuffer, p []byte) error {
+	rb.Write(p) // want "result of Write is ignored"
+	_, err := rb.Write(p)
+	return err
+}
+
+func reads(rb *ringbuffer.RingBuffer, n int) {
+	rb.ReadExact(8, make([]byte, 4)) // want "ReadExact of 8 bytes into a dst of length 4"
+	var arr [16]byte
+	rb.ReadExact(16, arr[:])
+	rb.ReadExact(16, arr[4:]) // want "ReadExact of 16 bytes into a dst of length 12"
+	buf := make([]byte, 2)
+	rb.ReadExact(3, buf) // want "ReadExact of 3 bytes into a dst of length 2"
+	grown := make([]byte, 2)
+	grown = make([]byte, 8)
+	rb.ReadExact(3, grown)
+	rb.ReadExact(n, buf)
+	rb.Read(buf)
+}
+
+func iovec(rb, other *ringbuffer.RingBuffer) int {
+	iov := rb.ReadIovec(64)
+	n := len(iov)
+	rb.ConsumeIovec(iov)
+	n += len(iov[0]) // want "iov holds ReadIovec views released by ConsumeIovec"
+
+	iov = other.ReadIovec(64)
+	return n + len(iov)
+}
@@ -0,0 +1,11 @@
+// Package ringbuffer is a stub of the real package's API for the
+// analyzer tests.
+package ringbuffer
+
+type RingBuffer struct{}
+
+func (rb *RingBuffer) Write(data []byte) (int, error)           { return 0, nil }
+func (rb *RingBuffer) Read(dst []byte) (int, error)             { return 0, nil }
+func (rb *RingBuffer) ReadExact(n int, dst []byte) (int, error) { return 0, nil }
+func (rb *RingBuffer) ReadIovec(max int) [][]byte               { return nil }
+func (rb *RingBuffer) ConsumeIovec(iov [][]byte) error          { return nil }
@@ -0,0 +1,173 @@
+package ringbuffer
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+var ErrInvalidRTP = errors.New("invalid rtp packet")
+
+// RTPPacket is a parsed RTP packet; Payload aliases the parsed bytes.
+type RTPPacket struct {
+	PayloadType uint8
+	Marker      bool
+	Seq         uint16
+	Timestamp   uint32
+	SSRC        uint32
+	Payload     []byte
+}
+
+// ParseRTP parses an RTP version 2 packet, skipping CSRCs, the header
+// extension and padding.
+func ParseRTP(b []byte) (RTPPacket, error) {
+	if len(b) < 12 || b[0]>>6 != 2 {
+		return RTPPacket{}, ErrInvalidRTP
+	}
+	p := RTPPacket{
+		PayloadType: b[1] & 0x7f,
+		Marker:      b[1]&0x80 != 0,
+		Seq:         binary.BigEndian.Uint16(b[2:]),
+		Timestamp:   binary.BigEndian.Uint32(b[4:]),
+		SSRC:        binary.BigEndian.Uint32(b[8:]),
+	}
+	off := 12 + 4*int(b[0]&0x0f)
+	if b[0]&0x10 != 0 {
+		if len(b) < off+4 {
+			return RTPPacket{}, ErrInvalidRTP
+		}
+		off += 4 + 4*int(binary.BigEndian.Uint16(b[off+2:]))
+	}
+	end := len(b)
+	if b[0]&0x20 != 0 && end > 0 {
+		end -= int(b[end-1])
+	}
+	if off > end {
+		return RTPPacket{}, ErrInvalidRTP
+	}
+	p.Payload = b[off:end]
+
+	return p, nil
+}
+
+// RTPStats counts what an RTPReorder saw.
+type RTPStats struct {
+	Received uint64
+	// Reordered packets arrived after a higher sequence number but in
+	// time to be delivered in order.
+	Reordered uint64
+	// Lost sequence numbers were skipped when the window moved on.
+	Lost uint64
+	// Late packets arrived after their slot was released, including
+	// duplicates of delivered packets, and were discarded.
+	Late      uint64
+	Duplicate uint64
+}
+
+// RTPReorder restores RTP sequence order within a window of depth
+// packets. In-order packets move to an output ring as soon as they are
+// contiguous; a packet arriving beyond the window forces the oldest gaps
+// to be declared lost.
+type RTPReorder struct {
+	slots  [][]byte
+	filled []bool
+	// head is the slot of next; slots are indexed by offset from next
+	// so the window stays consistent across the sequence wrap.
+	head    int
+	next    uint16
+	highest uint16
+	started bool
+	out     *RingBuffer
+	stats   RTPStats
+}
+
+// NewRTPReorder creates a reorder buffer holding up to depth
+// out-of-order packets and capacity bytes of in-order output.
+func NewRTPReorder(depth int, capacity int) *RTPReorder {
+	if depth < 1 {
+		depth = 1
+	}
+
+	return &RTPReorder{
+		slots:  make([][]byte, depth),
+		filled: make([]bool, depth),
+		out:    NewRingBuffer(capacity, WithChunks()),
+	}
+}
+
+func (r *RTPReorder) Stats() RTPStats {
+	return r.stats
+}
+
+// Push accepts one raw RTP packet.
+func (r *RTPReorder) Push(pkt []byte) error {
+	p, err := ParseRTP(pkt)
+	if err != nil {
+		return err
+	}
+	if !r.started {
+		r.next, r.highest, r.started = p.Seq, p.Seq, true
+	}
+	r.stats.Received++
+
+	d := int(int16(p.Seq - r.next))
+	if d < 0 {
+		r.stats.Late++
+		return nil
+	}
+	if int16(p.Seq-r.highest) > 0 {
+		r.highest = p.Seq
+	} else if p.Seq != r.highest {
+		r.stats.Reordered++
+	}
+	for d >= len(r.slots) {
+		if err := r.release(); err != nil {
+			return err
+		}
+		d--
+	}
+
+	i := (r.head + d) % len(r.slots)
+	if r.filled[i] {
+		r.stats.Duplicate++
+		return nil
+	}
+	r.slots[i] = append(r.slots[i][:0], pkt...)
+	r.filled[i] = true
+
+	for r.filled[r.head] {
+		if err := r.release(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// release moves the packet at next to the output, or counts it lost,
+// and advances next.
+func (r *RTPReorder) release() error {
+	if r.filled[r.head] {
+		if _, err := r.out.Write(r.slots[r.head]); err != nil {
+			return err
+		}
+		r.filled[r.head] = false
+	} else {
+		r.stats.Lost++
+	}
+	r.next++
+	r.head = (r.head + 1) % len(r.slots)
+
+	return nil
+}
+
+// Pop reads the next in-order packet into dst and returns it parsed. It
+// returns ErrEmpty when none is ready and io.ErrShortBuffer when dst is
+// too small.
+func (r *RTPReorder) Pop(dst []byte) (RTPPacket, error) {
+	n, err := r.out.ReadChunk(dst)
+	if err != nil {
+		return RTPPacket{}, err
+	}
+
+	return ParseRTP(dst[:n])
+}
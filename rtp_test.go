@@ -0,0 +1,90 @@
+package ringbuffer
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func rtpPacket(seq uint16, payload ...byte) []byte {
+	b := make([]byte, 12, 12+len(payload))
+	b[0] = 0x80
+	b[1] = 96
+	binary.BigEndian.PutUint16(b[2:], seq)
+	binary.BigEndian.PutUint32(b[4:], uint32(seq)*160)
+	binary.BigEndian.PutUint32(b[8:], 0xcafe)
+
+	return append(b, payload...)
+}
+
+func popSeqs(r *RTPReorder) []uint16 {
+	var seqs []uint16
+	buf := make([]byte, 64)
+	for {
+		p, err := r.Pop(buf)
+		if err != nil {
+			return seqs
+		}
+		seqs = append(seqs, p.Seq)
+	}
+}
+
+func Test_ParseRTP(t *testing.T) {
+
+	b := rtpPacket(7, 1, 2, 3)
+	b[0] |= 0x01 | 0x10 | 0x20 // one CSRC, extension, padding
+	b = append(b[:12], append([]byte{0, 0, 0, 1, 0xbe, 0xde, 0, 1, 9, 9, 9, 9}, b[12:]...)...)
+	b = append(b, 0, 2)
+
+	p, err := ParseRTP(b)
+	assert.Nil(t, err)
+	assert.Equal(t, uint16(7), p.Seq)
+	assert.Equal(t, uint32(1120), p.Timestamp)
+	assert.Equal(t, uint32(0xcafe), p.SSRC)
+	assert.Equal(t, uint8(96), p.PayloadType)
+	assert.Equal(t, []byte{1, 2, 3}, p.Payload)
+
+	_, err = ParseRTP([]byte{0x40, 0})
+	assert.Equal(t, ErrInvalidRTP, err)
+}
+
+func Test_RTPReorder(t *testing.T) {
+
+	r := NewRTPReorder(4, 1024)
+	for _, seq := range []uint16{65534, 0, 65535, 1, 1, 3} {
+		assert.Nil(t, r.Push(rtpPacket(seq, byte(seq))))
+	}
+	assert.Equal(t, []uint16{65534, 65535, 0, 1}, popSeqs(r))
+
+	// 2 is missing; 6 pushes the window past it.
+	r.Push(rtpPacket(6))
+	assert.Equal(t, []uint16{3}, popSeqs(r))
+	r.Push(rtpPacket(7))
+	r.Push(rtpPacket(7))
+	assert.Nil(t, popSeqs(r))
+	r.Push(rtpPacket(2))
+	r.Push(rtpPacket(4))
+	r.Push(rtpPacket(5))
+	assert.Equal(t, []uint16{4, 5, 6, 7}, popSeqs(r))
+
+	assert.Equal(t, RTPStats{Received: 12, Reordered: 3, Lost: 1, Late: 2, Duplicate: 1}, r.Stats())
+}
+
+func Test_RTPReorderWrapOddDepth(t *testing.T) {
+
+	// depth 3 does not divide 65536, so seq % depth slots would collide
+	// across the wrap
+	r := NewRTPReorder(3, 1024)
+	for _, seq := range []uint16{65534, 0, 65535} {
+		assert.Nil(t, r.Push(rtpPacket(seq)))
+	}
+	assert.Equal(t, []uint16{65534, 65535, 0}, popSeqs(r))
+	assert.Equal(t, RTPStats{Received: 3, Reordered: 1}, r.Stats())
+
+	for _, seq := range []uint16{2, 1, 4, 3} {
+		assert.Nil(t, r.Push(rtpPacket(seq)))
+	}
+	assert.Equal(t, []uint16{1, 2, 3, 4}, popSeqs(r))
+	assert.Equal(t, uint64(0), r.Stats().Lost)
+}
@@ -0,0 +1,93 @@
+package ringbuffer
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrSavepointStale is returned when the buffer was written to or reset
+// behind an open Savepoint.
+var ErrSavepointStale = errors.New("savepoint stale")
+
+// Savepoint stages writes in the free space after the buffered data
+// without making them visible to the reader. Release publishes all
+// staged bytes at once, as a single chunk in chunk mode; Rollback
+// discards them, so an encoder that learns mid-frame it must re-encode
+// can start over. Staged bytes never overrun, even in overwrite mode.
+type Savepoint struct {
+	rb    *RingBuffer
+	base  uint64
+	reset uint64
+	n     int
+}
+
+func (rb *RingBuffer) Savepoint() *Savepoint {
+	return &Savepoint{rb: rb, base: rb.written, reset: rb.resets}
+}
+
+// stale reports whether the buffer was written to or reset since the
+// savepoint was opened; ResetKeepStats leaves written unchanged, so
+// resets are tracked separately.
+func (sp *Savepoint) stale() bool {
+	return sp.rb.written != sp.base || sp.rb.resets != sp.reset
+}
+
+// Len returns the number of staged bytes.
+func (sp *Savepoint) Len() int {
+	return sp.n
+}
+
+func (sp *Savepoint) Write(data []byte) (int, error) {
+	rb := sp.rb
+	if sp.stale() {
+		return 0, ErrSavepointStale
+	}
+	if free := rb.capacity - rb.size - sp.n; len(data) > free {
+		errMsg := fmt.Sprintf("data len exceed capacity. %d > %d", len(data), free)
+		return 0, rb.violation(errors.New(errMsg))
+	}
+	s1, s2 := rb.writeSegments(sp.n + len(data))
+	if len(s1) > sp.n {
+		m := copy(s1[sp.n:], data)
+		copy(s2, data[m:])
+	} else {
+		copy(s2[sp.n-len(s1):], data)
+	}
+	sp.n += len(data)
+	// consumed bytes in the staged space are no longer recoverable
+	if free := rb.capacity - rb.size - sp.n; rb.unread > free {
+		rb.unread = free
+	}
+
+	return len(data), nil
+}
+
+// Rollback discards the staged bytes; the savepoint stays open.
+func (sp *Savepoint) Rollback() error {
+	sp.n = 0
+	if sp.stale() {
+		return ErrSavepointStale
+	}
+
+	return nil
+}
+
+// Release publishes the staged bytes to the reader and reopens the
+// savepoint at the new write position.
+func (sp *Savepoint) Release() error {
+	rb := sp.rb
+	if sp.stale() {
+		return ErrSavepointStale
+	}
+	if sp.n == 0 {
+		return nil
+	}
+	n := sp.n
+	rb.transformWritten(rb.writeSegments(n))
+	rb.advanceWrite(n)
+	rb.traceOp(TraceWrite, n)
+	sp.base = rb.written
+	sp.n = 0
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package ringbuffer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SavepointRelease(t *testing.T) {
+	rb := NewRingBuffer(8)
+	rb.Write([]byte{1, 2, 3, 4, 5, 6})
+	rb.DropOldest(5)
+
+	sp := rb.Savepoint()
+	sp.Write([]byte{7, 8})
+	sp.Write([]byte{9, 10, 11})
+	assert.Equal(t, 5, sp.Len())
+	assert.Equal(t, []byte{6}, rb.Bytes())
+
+	assert.Nil(t, sp.Release())
+	assert.Equal(t, []byte{6, 7, 8, 9, 10, 11}, rb.Bytes())
+	assert.Equal(t, uint64(11), rb.WrittenSequence())
+	assert.Equal(t, 0, sp.Len())
+}
+
+func Test_SavepointRollback(t *testing.T) {
+	rb := NewRingBuffer(4, WithChunks())
+	sp := rb.Savepoint()
+	sp.Write([]byte{1, 2, 3})
+	assert.Nil(t, sp.Rollback())
+	sp.Write([]byte{4, 5})
+	sp.Write([]byte{6})
+	sp.Release()
+
+	assert.Equal(t, []byte{4, 5, 6}, rb.Bytes())
+	assert.Equal(t, 1, rb.Chunks())
+
+	_, err := sp.Write([]byte{7, 8})
+	assert.Error(t, err)
+}
+
+func Test_SavepointStale(t *testing.T) {
+	rb := NewRingBuffer(8)
+	sp := rb.Savepoint()
+	sp.Write([]byte{1})
+	rb.Write([]byte{2})
+
+	_, err := sp.Write([]byte{3})
+	assert.Equal(t, ErrSavepointStale, err)
+	assert.Equal(t, ErrSavepointStale, sp.Release())
+	assert.Equal(t, []byte{2}, rb.Bytes())
+}
+
+func Test_SavepointUnread(t *testing.T) {
+	rb := NewRingBuffer(4)
+	rb.Write([]byte("abcd"))
+	rb.ReadExact(4, make([]byte, 4))
+
+	sp := rb.Savepoint()
+	sp.Write([]byte("XYZ"))
+	// staged bytes overwrote three of the consumed ones
+	assert.Error(t, rb.Unread(2))
+	assert.Nil(t, rb.Unread(1))
+	assert.Equal(t, []byte("d"), rb.Bytes())
+}
+
+func Test_SavepointReset(t *testing.T) {
+	rb := NewRingBuffer(8)
+	rb.Write([]byte("xy"))
+	sp := rb.Savepoint()
+	sp.Write([]byte("ab"))
+
+	rb.ResetKeepStats()
+	assert.Equal(t, ErrSavepointStale, sp.Release())
+	assert.Equal(t, 0, rb.Size())
+
+	sp = rb.Savepoint()
+	sp.Write([]byte("cd"))
+	rb.Reset()
+	assert.Equal(t, ErrSavepointStale, sp.Rollback())
+	_, err := sp.Write([]byte("e"))
+	assert.Equal(t, ErrSavepointStale, err)
+}
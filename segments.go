@@ -0,0 +1,33 @@
+//go:build !ringbuffer_unsafe
+
+package ringbuffer
+
+// writeSegments returns the n free bytes following writePos as up to two
+// slices of the backing array, split where the space wraps.
+func (rb *RingBuffer) writeSegments(n int) ([]byte, []byte) {
+	if n == 0 {
+		return nil, nil
+	}
+	if rb.writePos+n <= rb.capacity {
+		return rb.buf[rb.writePos : rb.writePos+n], nil
+	}
+
+	return rb.buf[rb.writePos:], rb.buf[:rb.writePos+n-rb.capacity]
+}
+
+// readSegments returns the readable bytes in [off, off+n) as up to two
+// slices of the backing array, split where the data wraps.
+func (rb *RingBuffer) readSegments(off, n int) ([]byte, []byte) {
+	if n == 0 {
+		return nil, nil
+	}
+	start := rb.readPos + off
+	if start >= rb.capacity {
+		start -= rb.capacity
+	}
+	if start+n <= rb.capacity {
+		return rb.buf[start : start+n], nil
+	}
+
+	return rb.buf[start:], rb.buf[:start+n-rb.capacity]
+}
@@ -0,0 +1,86 @@
+package ringbuffer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test_SegmentsConformance pins the segment contract that both the
+// portable and the ringbuffer_unsafe build must satisfy; run it with
+// and without the tag.
+func Test_SegmentsConformance(t *testing.T) {
+
+	for capacity := 1; capacity <= 9; capacity++ {
+		rb := NewRingBuffer(capacity)
+		for pos := 0; pos < capacity; pos++ {
+			for n := 0; n <= capacity; n++ {
+				for i := range rb.buf {
+					rb.buf[i] = byte(i)
+				}
+				want := make([]byte, n)
+				for i := range want {
+					want[i] = byte((pos + i) % capacity)
+				}
+
+				rb.writePos = pos
+				s1, s2 := rb.writeSegments(n)
+				assert.Equal(t, want, append(append([]byte{}, s1...), s2...))
+				assert.Equal(t, pos+n > capacity, len(s2) > 0)
+				for i := range s1 {
+					s1[i] = 0xf0
+				}
+				for i := range s2 {
+					s2[i] = 0xf0
+				}
+				for i := 0; i < n; i++ {
+					assert.Equal(t, byte(0xf0), rb.buf[(pos+i)%capacity])
+				}
+
+				for off := 0; off+n <= capacity; off++ {
+					for i := range rb.buf {
+						rb.buf[i] = byte(i)
+					}
+					for i := range want {
+						want[i] = byte((pos + off + i) % capacity)
+					}
+					rb.readPos = pos
+					r1, r2 := rb.readSegments(off, n)
+					assert.Equal(t, want, append(append([]byte{}, r1...), r2...))
+					assert.Equal(t, (pos+off)%capacity+n > capacity, len(r2) > 0)
+				}
+			}
+		}
+	}
+}
+
+func Test_SegmentsStream(t *testing.T) {
+
+	rb := NewRingBuffer(7)
+	var in, out []byte
+	next := byte(0)
+	dst := make([]byte, 7)
+	for step := 0; step < 200; step++ {
+		w := step % 5
+		if w > rb.AvailableWriteSize() {
+			w = rb.AvailableWriteSize()
+		}
+		p := make([]byte, w)
+		for i := range p {
+			p[i] = next
+			next++
+		}
+		n, err := rb.Write(p)
+		assert.NoError(t, err)
+		in = append(in, p[:n]...)
+
+		r := (step * 3) % 6
+		if r > rb.Size() {
+			r = rb.Size()
+		}
+		n, err = rb.ReadExact(r, dst)
+		assert.NoError(t, err)
+		out = append(out, dst[:n]...)
+	}
+	assert.Equal(t, in[:len(out)], out)
+}
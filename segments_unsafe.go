@@ -0,0 +1,43 @@
+//go:build ringbuffer_unsafe
+
+package ringbuffer
+
+import "unsafe"
+
+// The ringbuffer_unsafe build derives segments by pointer arithmetic on
+// the backing array, skipping the bounds checks of the portable build.
+// Callers already guarantee that positions and n are within capacity.
+
+// writeSegments returns the n free bytes following writePos as up to two
+// slices of the backing array, split where the space wraps.
+func (rb *RingBuffer) writeSegments(n int) ([]byte, []byte) {
+	if n == 0 {
+		return nil, nil
+	}
+
+	return rb.segments(rb.writePos, n)
+}
+
+// readSegments returns the readable bytes in [off, off+n) as up to two
+// slices of the backing array, split where the data wraps.
+func (rb *RingBuffer) readSegments(off, n int) ([]byte, []byte) {
+	if n == 0 {
+		return nil, nil
+	}
+	start := rb.readPos + off
+	if start >= rb.capacity {
+		start -= rb.capacity
+	}
+
+	return rb.segments(start, n)
+}
+
+func (rb *RingBuffer) segments(start, n int) ([]byte, []byte) {
+	base := unsafe.Pointer(&rb.buf[0])
+	if start+n <= rb.capacity {
+		return unsafe.Slice((*byte)(unsafe.Add(base, start)), n), nil
+	}
+	first := rb.capacity - start
+
+	return unsafe.Slice((*byte)(unsafe.Add(base, start)), first), unsafe.Slice((*byte)(base), n-first)
+}
@@ -0,0 +1,35 @@
+//go:build !tinygo
+
+package ringbuffer
+
+import (
+	"context"
+	"reflect"
+)
+
+// Select blocks until one of bufs has data to read or is closed and
+// returns its index, for mixers consuming many sources. When several are
+// ready the lowest index wins, so callers should drain every ready
+// buffer before selecting again. It returns ctx.Err() if ctx ends first.
+func Select(ctx context.Context, bufs ...*BlockingRingBuffer) (int, error) {
+	cases := make([]reflect.SelectCase, len(bufs)+1)
+	cases[len(bufs)] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())}
+	for {
+		for i, b := range bufs {
+			b.mu.Lock()
+			ready := b.rb.Size() > 0 || b.closed
+			changed := b.changed
+			b.mu.Unlock()
+			if ready {
+				return i, nil
+			}
+			cases[i] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(changed)}
+		}
+		if err := ctx.Err(); err != nil {
+			return -1, err
+		}
+		if chosen, _, _ := reflect.Select(cases); chosen == len(bufs) {
+			return -1, ctx.Err()
+		}
+	}
+}
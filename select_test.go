@@ -0,0 +1,58 @@
+//go:build !tinygo
+
+package ringbuffer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SelectReady(t *testing.T) {
+
+	a := NewBlockingRingBuffer(8, nil)
+	b := NewBlockingRingBuffer(8, nil)
+	b.Write([]byte{1})
+
+	i, err := Select(context.Background(), a, b)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, i)
+
+	a.Close()
+	i, _ = Select(context.Background(), a, b)
+	assert.Equal(t, 0, i)
+}
+
+func Test_SelectWaits(t *testing.T) {
+
+	bufs := []*BlockingRingBuffer{
+		NewBlockingRingBuffer(8, nil),
+		NewBlockingRingBuffer(8, nil),
+		NewBlockingRingBuffer(8, nil),
+	}
+	done := make(chan int)
+	go func() {
+		i, _ := Select(context.Background(), bufs...)
+		done <- i
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	bufs[2].Write([]byte{1})
+	assert.Equal(t, 2, <-done)
+}
+
+func Test_SelectCancel(t *testing.T) {
+
+	a := NewBlockingRingBuffer(8, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	i, err := Select(ctx, a)
+	assert.Equal(t, -1, i)
+	assert.Equal(t, context.Canceled, err)
+}
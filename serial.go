@@ -0,0 +1,106 @@
+package ringbuffer
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// SerialIngest buffers a raw byte stream, such as a serial port or TTY,
+// and delimits frames by idle gaps: bytes arriving within Gap of the
+// previous ones extend the current frame, a longer pause starts a new
+// one. It is safe for one writer and one reader.
+type SerialIngest struct {
+	mu    sync.Mutex
+	rb    *RingBuffer
+	gap   time.Duration
+	clock Clock
+	last  time.Time
+}
+
+// NewSerialIngest creates an ingest buffer. A nil clock selects the
+// system clock.
+func NewSerialIngest(capacity int, gap time.Duration, clock Clock) *SerialIngest {
+	if clock == nil {
+		clock = systemClock{}
+	}
+
+	return &SerialIngest{
+		rb:    NewRingBuffer(capacity, WithChunks()),
+		gap:   gap,
+		clock: clock,
+	}
+}
+
+// Write stores p, extending the open frame if it arrives within the gap.
+func (s *SerialIngest) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(p) == 0 {
+		return 0, nil
+	}
+	now := s.clock.Now()
+	extend := s.rb.Chunks() > 0 && now.Sub(s.last) <= s.gap
+	n, err := s.rb.Write(p)
+	if err != nil {
+		return n, err
+	}
+	if extend {
+		s.rb.mergeNewestChunks()
+	}
+	s.last = now
+
+	return n, nil
+}
+
+// Ingest copies r into the buffer until r fails; io.EOF ends it cleanly.
+func (s *SerialIngest) Ingest(r io.Reader) error {
+	buf := make([]byte, 512)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if _, werr := s.Write(buf[:n]); werr != nil {
+				return werr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Frames returns the number of complete frames: the newest one counts
+// only once the gap has passed without more bytes.
+func (s *SerialIngest) Frames() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.frames()
+}
+
+func (s *SerialIngest) frames() int {
+	n := s.rb.Chunks()
+	if n > 0 && s.clock.Now().Sub(s.last) <= s.gap {
+		n--
+	}
+
+	return n
+}
+
+// ReadFrame reads the oldest complete frame into dst. It returns ErrEmpty
+// when no frame is complete and io.ErrShortBuffer, consuming nothing,
+// when dst is too small.
+func (s *SerialIngest) ReadFrame(dst []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.frames() == 0 {
+		return 0, ErrEmpty
+	}
+
+	return s.rb.ReadChunk(dst)
+}
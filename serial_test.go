@@ -0,0 +1,51 @@
+package ringbuffer
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/drgolem/ringbuffer/fakeclock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SerialIngestIdleGap(t *testing.T) {
+
+	clock := fakeclock.New(time.Unix(1000, 0))
+	s := NewSerialIngest(32, 5*time.Millisecond, clock)
+
+	s.Write([]byte{1, 2})
+	clock.Advance(time.Millisecond)
+	s.Write([]byte{3})
+	assert.Equal(t, 0, s.Frames())
+
+	clock.Advance(10 * time.Millisecond)
+	assert.Equal(t, 1, s.Frames())
+	s.Write([]byte{4})
+	clock.Advance(2 * time.Millisecond)
+	s.Write([]byte{5, 6})
+	assert.Equal(t, 1, s.Frames())
+
+	out := make([]byte, 8)
+	n, err := s.ReadFrame(out)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, out[:n])
+	_, err = s.ReadFrame(out)
+	assert.Equal(t, ErrEmpty, err)
+
+	clock.Advance(6 * time.Millisecond)
+	n, _ = s.ReadFrame(out)
+	assert.Equal(t, []byte{4, 5, 6}, out[:n])
+}
+
+func Test_SerialIngestReader(t *testing.T) {
+
+	clock := fakeclock.New(time.Unix(1000, 0))
+	s := NewSerialIngest(32, time.Millisecond, clock)
+	assert.Nil(t, s.Ingest(bytes.NewReader([]byte("frame"))))
+
+	clock.Advance(2 * time.Millisecond)
+	out := make([]byte, 8)
+	n, _ := s.ReadFrame(out)
+	assert.Equal(t, []byte("frame"), out[:n])
+}
@@ -0,0 +1,83 @@
+package ringbuffer
+
+import (
+	"sync"
+)
+
+// ShardedRing gives each producer its own ring so producers never contend
+// with each other; a single reader drains the shards round-robin. Each
+// shard is guarded by its own mutex, shared only by its producer and the
+// reader.
+type ShardedRing struct {
+	shards []shard
+	next   int
+}
+
+type shard struct {
+	mu sync.Mutex
+	rb *RingBuffer
+}
+
+func NewShardedRing(shards int, capacity int) *ShardedRing {
+	sr := &ShardedRing{
+		shards: make([]shard, shards),
+	}
+	for i := range sr.shards {
+		sr.shards[i].rb = NewRingBuffer(capacity)
+	}
+
+	return sr
+}
+
+func (sr *ShardedRing) Shards() int {
+	return len(sr.shards)
+}
+
+// Write appends data to shard i. Each producer should stick to its own
+// shard index.
+func (sr *ShardedRing) Write(i int, data []byte) (int, error) {
+	s := &sr.shards[i]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.rb.Write(data)
+}
+
+func (sr *ShardedRing) Size() int {
+	size := 0
+	for i := range sr.shards {
+		s := &sr.shards[i]
+		s.mu.Lock()
+		size += s.rb.Size()
+		s.mu.Unlock()
+	}
+
+	return size
+}
+
+// Read reads up to len(dst) bytes from the next non-empty shard in
+// round-robin order and reports which shard they came from. It returns
+// ErrEmpty when every shard is empty. Read must only be called from one
+// goroutine at a time.
+func (sr *ShardedRing) Read(dst []byte) (int, int, error) {
+	for k := 0; k < len(sr.shards); k++ {
+		i := (sr.next + k) % len(sr.shards)
+		s := &sr.shards[i]
+		s.mu.Lock()
+		n := s.rb.Size()
+		if n == 0 {
+			s.mu.Unlock()
+			continue
+		}
+		if n > len(dst) {
+			n = len(dst)
+		}
+		n, err := s.rb.ReadExact(n, dst)
+		s.mu.Unlock()
+		sr.next = (i + 1) % len(sr.shards)
+
+		return i, n, err
+	}
+
+	return -1, 0, ErrEmpty
+}
@@ -0,0 +1,74 @@
+package ringbuffer
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ShardedRingRoundRobin(t *testing.T) {
+
+	sr := NewShardedRing(3, 8)
+	assert.Equal(t, 3, sr.Shards())
+
+	sr.Write(0, []byte{1, 2})
+	sr.Write(2, []byte{20, 21})
+
+	out := make([]byte, 1)
+	var shards []int
+	var data []byte
+	for {
+		i, n, err := sr.Read(out)
+		if err != nil {
+			assert.ErrorIs(t, err, ErrEmpty)
+			break
+		}
+		shards = append(shards, i)
+		data = append(data, out[:n]...)
+	}
+	assert.Equal(t, []int{0, 2, 0, 2}, shards)
+	assert.Equal(t, []byte{1, 20, 2, 21}, data)
+}
+
+func Test_ShardedRingConcurrent(t *testing.T) {
+
+	const producers = 4
+	const perProducer = 1000
+
+	sr := NewShardedRing(producers, 64)
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perProducer; {
+				if _, err := sr.Write(p, []byte{byte(i)}); err != nil {
+					runtime.Gosched()
+					continue
+				}
+				i++
+			}
+		}(p)
+	}
+
+	counts := make([]int, producers)
+	total := 0
+	out := make([]byte, 16)
+	for total < producers*perProducer {
+		i, n, err := sr.Read(out)
+		if err != nil {
+			runtime.Gosched()
+			continue
+		}
+		for _, b := range out[:n] {
+			assert.Equal(t, byte(counts[i]), b)
+			counts[i]++
+		}
+		total += n
+	}
+	wg.Wait()
+	assert.Equal(t, 0, sr.Size())
+}
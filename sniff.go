@@ -0,0 +1,20 @@
+//go:build !tinygo
+
+package ringbuffer
+
+import "net/http"
+
+const sniffLen = 512
+
+// SniffType runs http.DetectContentType on the first 512 buffered bytes
+// without consuming them.
+func (rb *RingBuffer) SniffType() string {
+	var head [sniffLen]byte
+	n := rb.size
+	if n > sniffLen {
+		n = sniffLen
+	}
+	rb.copyOut(head[:], 0, n)
+
+	return http.DetectContentType(head[:n])
+}
@@ -0,0 +1,24 @@
+//go:build !tinygo
+
+package ringbuffer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SniffType(t *testing.T) {
+
+	rb := NewRingBuffer(16)
+	rb.Write([]byte("0123456789"))
+	rb.DropOldest(10)
+	rb.Write([]byte("OggS\x00\x02\x00\x00"))
+
+	assert.Equal(t, "application/ogg", rb.SniffType())
+	assert.Equal(t, 8, rb.Size())
+
+	rb.Reset()
+	rb.Write([]byte("<html><body>"))
+	assert.Equal(t, "text/html; charset=utf-8", rb.SniffType())
+}
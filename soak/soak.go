@@ -0,0 +1,377 @@
+// Package soak runs configurable producer/consumer workloads against a
+// ring and verifies that every byte arrives intact and in order. It is
+// meant for validating a buffer configuration on the target hardware,
+// from tests or from a small command.
+package soak
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/drgolem/ringbuffer"
+)
+
+// ErrCorrupt is returned when a record arrives damaged, out of order or
+// from an unknown producer.
+var ErrCorrupt = errors.New("soak: corrupted record")
+
+// Ring is the part of a buffer the harness drives. Write and Read may
+// transfer fewer bytes than asked; a call that transfers nothing is
+// retried. BlockingRingBuffer implements Ring directly; Locked and Audio
+// adapt the other variants.
+type Ring interface {
+	Write(p []byte) (int, error)
+	Read(p []byte) (int, error)
+}
+
+type Config struct {
+	Producers int
+	Consumers int
+	// Records is the number of records each producer writes.
+	Records int
+	// MinSize and MaxSize bound the payload size of a record.
+	MinSize int
+	MaxSize int
+	// Burst records are written back to back, followed by Pause.
+	Burst int
+	Pause time.Duration
+	// ReadSize is the size of each consumer read.
+	ReadSize int
+	// Align pads every record to a multiple of Align bytes; set it to
+	// the frame size for rings that only move whole frames.
+	Align int
+	Seed  int64
+}
+
+type Report struct {
+	Records int
+	Bytes   int64
+	Elapsed time.Duration
+}
+
+// Throughput returns the verified bytes per second.
+func (r Report) Throughput() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+
+	return float64(r.Bytes) / r.Elapsed.Seconds()
+}
+
+// header: producer uint32, payload length uint32, sequence uint64.
+const headerSize = 16
+
+// Run drives r with cfg until every record has been read back and
+// verified, an error occurs or ctx ends. Byte rings do not keep records
+// from concurrent writers together, so whole records are serialized
+// among producers and among consumers; the two sides still run
+// concurrently. If r implements io.Closer it is closed when ctx ends so
+// that blocked calls return.
+func Run(ctx context.Context, r Ring, cfg Config) (Report, error) {
+	cfg, err := cfg.normalize()
+	if err != nil {
+		return Report{}, err
+	}
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if c, ok := r.(io.Closer); ok {
+		closed := make(chan struct{})
+		defer close(closed)
+		go func() {
+			select {
+			case <-ctx.Done():
+				c.Close()
+			case <-closed:
+			}
+		}()
+	}
+
+	s := &session{
+		r:    r,
+		cfg:  cfg,
+		next: make([]uint64, cfg.Producers),
+		want: cfg.Producers * cfg.Records,
+	}
+	var wg sync.WaitGroup
+	errs := make(chan error, cfg.Producers+cfg.Consumers)
+	start := time.Now()
+	for i := 0; i < cfg.Producers; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			if err := s.produce(ctx, id); err != nil {
+				errs <- err
+				cancel()
+			}
+		}(i)
+	}
+	for i := 0; i < cfg.Consumers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := s.consume(ctx); err != nil {
+				errs <- err
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+	rep := Report{Records: s.got, Bytes: s.bytes, Elapsed: time.Since(start)}
+
+	if s.got < s.want {
+		if err := parent.Err(); err != nil {
+			return rep, err
+		}
+		// The first failure is queued before cancel, so later
+		// context errors from the other goroutines do not mask it.
+		return rep, <-errs
+	}
+
+	return rep, nil
+}
+
+func (cfg Config) normalize() (Config, error) {
+	if cfg.Producers == 0 {
+		cfg.Producers = 1
+	}
+	if cfg.Consumers == 0 {
+		cfg.Consumers = 1
+	}
+	if cfg.Burst == 0 {
+		cfg.Burst = 1
+	}
+	if cfg.ReadSize == 0 {
+		cfg.ReadSize = 4096
+	}
+	if cfg.Align == 0 {
+		cfg.Align = 1
+	}
+	if cfg.MaxSize < cfg.MinSize {
+		cfg.MaxSize = cfg.MinSize
+	}
+	if cfg.Producers < 0 || cfg.Consumers < 0 || cfg.Records < 0 || cfg.MinSize < 0 ||
+		cfg.Burst < 0 || cfg.ReadSize < 0 || cfg.Align < 0 {
+		return cfg, errors.New(fmt.Sprintf("soak: invalid config %+v", cfg))
+	}
+
+	return cfg, nil
+}
+
+type session struct {
+	r   Ring
+	cfg Config
+
+	wmu sync.Mutex
+
+	rmu     sync.Mutex
+	pending []byte
+	next    []uint64
+	got     int
+	want    int
+	bytes   int64
+}
+
+func (s *session) produce(ctx context.Context, id int) error {
+	rnd := rand.New(rand.NewSource(s.cfg.Seed + int64(id)))
+	var rec []byte
+	for seq := 0; seq < s.cfg.Records; seq++ {
+		size := s.cfg.MinSize
+		if s.cfg.MaxSize > s.cfg.MinSize {
+			size += rnd.Intn(s.cfg.MaxSize - s.cfg.MinSize + 1)
+		}
+		if pad := (headerSize + size) % s.cfg.Align; pad != 0 {
+			size += s.cfg.Align - pad
+		}
+		rec = appendRecord(rec[:0], uint32(id), uint64(seq), size)
+		if err := s.write(ctx, rec); err != nil {
+			return err
+		}
+		if s.cfg.Pause > 0 && (seq+1)%s.cfg.Burst == 0 {
+			select {
+			case <-time.After(s.cfg.Pause):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *session) write(ctx context.Context, rec []byte) error {
+	if s.cfg.Producers > 1 {
+		s.wmu.Lock()
+		defer s.wmu.Unlock()
+	}
+	for len(rec) > 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		n, err := s.r.Write(rec)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			runtime.Gosched()
+		}
+		rec = rec[n:]
+	}
+
+	return nil
+}
+
+func (s *session) consume(ctx context.Context) error {
+	buf := make([]byte, s.cfg.ReadSize)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		done, err := s.read(buf)
+		if err != nil || done {
+			return err
+		}
+	}
+}
+
+// read performs one read and verifies every record it completes. It
+// reports done once all records have been verified.
+func (s *session) read(buf []byte) (bool, error) {
+	if s.cfg.Consumers > 1 {
+		s.rmu.Lock()
+		defer s.rmu.Unlock()
+	}
+	if s.got == s.want {
+		return true, nil
+	}
+	n, err := s.r.Read(buf)
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return false, err
+	}
+	if n == 0 {
+		runtime.Gosched()
+		return false, nil
+	}
+	s.pending = append(s.pending, buf[:n]...)
+	for len(s.pending) >= headerSize {
+		size := int(binary.LittleEndian.Uint32(s.pending[4:]))
+		if size > s.cfg.MaxSize+s.cfg.Align {
+			return false, fmt.Errorf("%w: payload length %d", ErrCorrupt, size)
+		}
+		if len(s.pending) < headerSize+size {
+			break
+		}
+		if err := s.verify(s.pending[:headerSize+size]); err != nil {
+			return false, err
+		}
+		s.pending = s.pending[headerSize+size:]
+		s.got++
+		s.bytes += int64(headerSize + size)
+	}
+	if len(s.pending) == 0 {
+		s.pending = s.pending[:0:0]
+	}
+
+	return s.got == s.want, nil
+}
+
+func (s *session) verify(rec []byte) error {
+	id := binary.LittleEndian.Uint32(rec)
+	seq := binary.LittleEndian.Uint64(rec[8:])
+	if int(id) >= len(s.next) {
+		return fmt.Errorf("%w: unknown producer %d", ErrCorrupt, id)
+	}
+	if seq != s.next[id] {
+		return fmt.Errorf("%w: producer %d sent seq %d, want %d", ErrCorrupt, id, seq, s.next[id])
+	}
+	x := payloadSeed(id, seq)
+	for i, b := range rec[headerSize:] {
+		if b != byte(x+uint64(i)) {
+			return fmt.Errorf("%w: producer %d seq %d differs at byte %d", ErrCorrupt, id, seq, i)
+		}
+	}
+	s.next[id]++
+
+	return nil
+}
+
+func appendRecord(dst []byte, id uint32, seq uint64, size int) []byte {
+	var h [headerSize]byte
+	binary.LittleEndian.PutUint32(h[0:], id)
+	binary.LittleEndian.PutUint32(h[4:], uint32(size))
+	binary.LittleEndian.PutUint64(h[8:], seq)
+	dst = append(dst, h[:]...)
+	x := payloadSeed(id, seq)
+	for i := 0; i < size; i++ {
+		dst = append(dst, byte(x+uint64(i)))
+	}
+
+	return dst
+}
+
+// payloadSeed mixes the record identity (splitmix64) so that misplaced
+// bytes from another record are unlikely to verify.
+func payloadSeed(id uint32, seq uint64) uint64 {
+	z := seq<<16 ^ uint64(id) + 0x9e3779b97f4a7c15
+	z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+	z = (z ^ z>>27) * 0x94d049bb133111eb
+
+	return z ^ z>>31
+}
+
+type locked struct {
+	mu sync.Mutex
+	rb *ringbuffer.RingBuffer
+}
+
+// Locked adapts a RingBuffer, which is not safe for concurrent use, by
+// guarding it with a mutex.
+func Locked(rb *ringbuffer.RingBuffer) Ring {
+	return &locked{rb: rb}
+}
+
+func (l *locked) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.rb.WriteAvailable(p), nil
+}
+
+func (l *locked) Read(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	n := l.rb.Size()
+	if n > len(p) {
+		n = len(p)
+	}
+
+	return l.rb.ReadExact(n, p)
+}
+
+type audio struct {
+	ar *ringbuffer.AudioRing
+}
+
+// Audio adapts an AudioRing. Run with Align set to the frame size.
+func Audio(ar *ringbuffer.AudioRing) Ring {
+	return audio{ar: ar}
+}
+
+func (a audio) Write(p []byte) (int, error) {
+	return a.ar.Write(p), nil
+}
+
+func (a audio) Read(p []byte) (int, error) {
+	return a.ar.Read(p), nil
+}
@@ -0,0 +1,83 @@
+package soak
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/drgolem/ringbuffer"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RunLocked(t *testing.T) {
+
+	rb := ringbuffer.NewRingBuffer(256)
+	cfg := Config{Producers: 3, Consumers: 2, Records: 200, MinSize: 0, MaxSize: 100, Burst: 8, ReadSize: 37}
+	rep, err := Run(context.Background(), Locked(rb), cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, 600, rep.Records)
+	assert.True(t, rep.Bytes >= 600*headerSize)
+	assert.Equal(t, 0, rb.Size())
+}
+
+func Test_RunBlocking(t *testing.T) {
+
+	b := ringbuffer.NewBlockingRingBuffer(64, nil)
+	cfg := Config{Producers: 2, Records: 100, MinSize: 10, MaxSize: 200, ReadSize: 16}
+	rep, err := Run(context.Background(), b, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, rep.Records)
+}
+
+func Test_RunAudio(t *testing.T) {
+
+	ar, err := ringbuffer.NewAudioRing(96, ringbuffer.PCMFormat{SampleRate: 48000, Channels: 2, BitsPerSample: 24})
+	assert.NoError(t, err)
+	cfg := Config{Records: 300, MinSize: 1, MaxSize: 40, Align: 6, ReadSize: 30}
+	rep, err := Run(context.Background(), Audio(ar), cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, 300, rep.Records)
+	assert.Equal(t, int64(0), rep.Bytes%6)
+}
+
+// flipper corrupts one byte of the stream.
+type flipper struct {
+	Ring
+	at, seen int
+}
+
+func (f *flipper) Read(p []byte) (int, error) {
+	n, err := f.Ring.Read(p)
+	if f.seen <= f.at && f.at < f.seen+n {
+		p[f.at-f.seen] ^= 0xff
+	}
+	f.seen += n
+
+	return n, err
+}
+
+func Test_RunDetectsCorruption(t *testing.T) {
+
+	rb := ringbuffer.NewRingBuffer(128)
+	r := &flipper{Ring: Locked(rb), at: 500}
+	_, err := Run(context.Background(), r, Config{Records: 100, MinSize: 20, MaxSize: 20})
+	assert.True(t, errors.Is(err, ErrCorrupt))
+}
+
+func Test_RunContext(t *testing.T) {
+
+	b := ringbuffer.NewBlockingRingBuffer(64, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	rep, err := Run(ctx, b, Config{Records: 10, Burst: 1, Pause: time.Hour})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, rep.Records)
+}
+
+func Test_RunInvalidConfig(t *testing.T) {
+
+	rb := ringbuffer.NewRingBuffer(16)
+	_, err := Run(context.Background(), Locked(rb), Config{Records: -1})
+	assert.Error(t, err)
+}
@@ -0,0 +1,87 @@
+package ringbuffer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"time"
+)
+
+const (
+	// defaultSpinPolls empty polls, a few microseconds of two atomic
+	// loads each, run before the consumer yields. More polls shave the
+	// wake-up latency further but burn the core longer when idle.
+	defaultSpinPolls = 4096
+	// defaultSpinChunk bytes are handed to the callback at most at once.
+	defaultSpinChunk = 4096
+)
+
+// SpinConfig tunes SpinConsume. Zero fields take the defaults.
+type SpinConfig struct {
+	// Polls is the number of empty polls before runtime.Gosched, so the
+	// spinning goroutine never starves others on the same P for long.
+	Polls int
+	// Chunk is the most bytes passed to the callback per call; it is
+	// rounded down to whole frames.
+	Chunk int
+	// IdleSleep, when set, is slept instead of yielding once the ring
+	// has been empty for Polls polls. It saves the core during long
+	// gaps at the cost of the timer granularity in latency, which is
+	// often a millisecond; leave it zero for a pure spin.
+	IdleSleep time.Duration
+}
+
+// SpinConsume is an ultra-low-latency consumer loop: it locks the
+// calling goroutine to its OS thread and busy-polls the ring, calling fn
+// with each batch of whole frames as soon as it is written, until ctx
+// ends. Run it on its own goroutine; pin that thread to a core with the
+// OS tools (taskset, SetThreadAffinityMask) for the best results.
+//
+// Spinning keeps one core at 100% while the stream runs and only pays
+// off when sub-100µs wake-ups matter and a core can be dedicated. fn
+// runs on the spinning thread and must not block; p is reused between
+// calls.
+func (ar *AudioRing) SpinConsume(ctx context.Context, cfg SpinConfig, fn func(p []byte)) error {
+	if cfg.Polls <= 0 {
+		cfg.Polls = defaultSpinPolls
+	}
+	if cfg.Chunk <= 0 {
+		cfg.Chunk = defaultSpinChunk
+	}
+	cfg.Chunk -= cfg.Chunk % ar.frame
+	if cfg.Chunk == 0 {
+		return errors.New(fmt.Sprintf("spin chunk smaller than frame size %d", ar.frame))
+	}
+
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	buf := make([]byte, cfg.Chunk)
+	done := ctx.Done()
+	idle := 0
+	for {
+		// checked before every pop so a producer that never lets the
+		// ring drain cannot keep the loop from ending
+		select {
+		case <-done:
+			return ctx.Err()
+		default:
+		}
+		if n := ar.pop(buf); n > 0 {
+			fn(buf[:n])
+			idle = 0
+			continue
+		}
+		idle++
+		if idle < cfg.Polls {
+			continue
+		}
+		idle = 0
+		if cfg.IdleSleep > 0 {
+			time.Sleep(cfg.IdleSleep)
+		} else {
+			runtime.Gosched()
+		}
+	}
+}
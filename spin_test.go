@@ -0,0 +1,86 @@
+package ringbuffer
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SpinConsume(t *testing.T) {
+
+	f := PCMFormat{SampleRate: 48000, Channels: 2, BitsPerSample: 16}
+	ar, err := NewAudioRing(64, f)
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	got := make(chan []byte, 1)
+	errc := make(chan error, 1)
+	go func() {
+		var all []byte
+		errc <- ar.SpinConsume(ctx, SpinConfig{Polls: 16, Chunk: 10}, func(p []byte) {
+			assert.Equal(t, 0, len(p)%4)
+			assert.True(t, len(p) <= 8)
+			all = append(all, p...)
+			if len(all) == 400 {
+				got <- all
+			}
+		})
+	}()
+
+	want := make([]byte, 400)
+	for i := range want {
+		want[i] = byte(i)
+	}
+	for p := want; len(p) > 0; {
+		n := ar.Write(p)
+		p = p[n:]
+		if n == 0 {
+			runtime.Gosched()
+		}
+	}
+	select {
+	case all := <-got:
+		assert.Equal(t, want, all)
+	case <-time.After(5 * time.Second):
+		t.Fatal("consumer did not receive the stream")
+	}
+	cancel()
+	assert.Equal(t, context.Canceled, <-errc)
+}
+
+func Test_SpinConsumeIdleSleep(t *testing.T) {
+
+	ar, _ := NewAudioRing(8, PCMFormat{SampleRate: 8000, Channels: 1, BitsPerSample: 8})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := ar.SpinConsume(ctx, SpinConfig{Polls: 1, IdleSleep: time.Millisecond}, func(p []byte) {})
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	wide, _ := NewAudioRing(48, PCMFormat{SampleRate: 8000, Channels: 6, BitsPerSample: 16})
+	assert.Error(t, wide.SpinConsume(ctx, SpinConfig{Chunk: 8}, func(p []byte) {}))
+}
+
+func Test_SpinConsumeCancelUnderLoad(t *testing.T) {
+
+	f := PCMFormat{SampleRate: 48000, Channels: 2, BitsPerSample: 16}
+	ar, err := NewAudioRing(64, f)
+	assert.Nil(t, err)
+
+	ar.Write(make([]byte, 8))
+
+	// fn refills the ring, so it is never empty when the loop polls
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	err = ar.SpinConsume(ctx, SpinConfig{Chunk: 8}, func(p []byte) {
+		calls++
+		if calls == 10 {
+			cancel()
+		}
+		ar.Write(p)
+	})
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, 10, calls)
+}
@@ -0,0 +1,38 @@
+package ringbuffer
+
+import (
+	"fmt"
+	"io"
+)
+
+type splitWriter struct {
+	pred func(chunk []byte) int
+	outs []*RingBuffer
+}
+
+// Split returns a writer that routes every write, as one chunk, to
+// outs[pred(chunk)], e.g. by stream id. A negative index discards the
+// chunk. Like Fanout it never blocks: a write whose destination lacks
+// space fails without storing anything.
+func Split(pred func(chunk []byte) int, outs ...*RingBuffer) io.Writer {
+	return &splitWriter{
+		pred: pred,
+		outs: append([]*RingBuffer(nil), outs...),
+	}
+}
+
+func (sw *splitWriter) Write(p []byte) (int, error) {
+	i := sw.pred(p)
+	if i < 0 {
+		return len(p), nil
+	}
+	if i >= len(sw.outs) {
+		return 0, fmt.Errorf("split target %d out of range, have %d", i, len(sw.outs))
+	}
+	rb := sw.outs[i]
+	if rb.AvailableWriteSize() < len(p) && !rb.overwrite {
+		return 0, fmt.Errorf("split target %d: %d bytes free, need %d", i, rb.AvailableWriteSize(), len(p))
+	}
+
+	return rb.Write(p)
+}
@@ -0,0 +1,43 @@
+package ringbuffer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Split(t *testing.T) {
+
+	audio := NewRingBuffer(8, WithChunks())
+	control := NewRingBuffer(8, WithChunks())
+	w := Split(func(chunk []byte) int {
+		switch chunk[0] {
+		case 'a':
+			return 0
+		case 'c':
+			return 1
+		}
+		return -1
+	}, audio, control)
+
+	for _, msg := range []string{"a1", "c1", "x", "a22"} {
+		n, err := w.Write([]byte(msg))
+		assert.Nil(t, err)
+		assert.Equal(t, len(msg), n)
+	}
+	assert.Equal(t, 2, audio.Chunks())
+	assert.Equal(t, []byte("a1a22"), audio.Bytes())
+	assert.Equal(t, []byte("c1"), control.Bytes())
+
+	_, err := w.Write([]byte("a4444"))
+	assert.Error(t, err)
+	assert.Equal(t, 5, audio.Size())
+}
+
+func Test_SplitOutOfRange(t *testing.T) {
+
+	rb := NewRingBuffer(8)
+	w := Split(func([]byte) int { return 1 }, rb)
+	_, err := w.Write([]byte{1})
+	assert.Error(t, err)
+}
@@ -0,0 +1,109 @@
+package ringbuffer
+
+import (
+	"sort"
+)
+
+type Stats struct {
+	Capacity int
+	Size     int
+	Written  uint64
+	Consumed uint64
+	Lost     uint64
+	// Expired counts bytes dropped by the TTL.
+	Expired uint64
+	// RecommendedCapacity is the size analyzer's suggestion, 0 when the
+	// analyzer is not enabled or has no samples yet.
+	RecommendedCapacity int
+	// IngressRate and EgressRate are bytes per second over the window
+	// set by WithRateWindow, 0 when it is not enabled.
+	IngressRate float64
+	EgressRate  float64
+	// Occupancy is a copy of the fill-level histogram enabled by
+	// WithOccupancyHistogram, nil otherwise.
+	Occupancy []uint64
+	// NUMANode is the node the backing array is bound to by
+	// WithNUMANode, -1 otherwise.
+	NUMANode int
+}
+
+func (rb *RingBuffer) Stats() Stats {
+	s := Stats{
+		Capacity:            rb.capacity,
+		Size:                rb.size,
+		Written:             rb.written,
+		Consumed:            rb.consumed,
+		Lost:                rb.lost,
+		Expired:             rb.ExpiredBytes(),
+		RecommendedCapacity: rb.RecommendedCapacity(),
+		NUMANode:            rb.NUMANode(),
+	}
+	if rb.rate != nil {
+		s.IngressRate, s.EgressRate = rb.rate.rates()
+	}
+	if rb.occupancy != nil {
+		s.Occupancy = append([]uint64(nil), rb.occupancy...)
+	}
+
+	return s
+}
+
+const (
+	defaultSizePercentile = 0.99
+	defaultSizeHeadroom   = 0.25
+)
+
+type sizeAnalyzer struct {
+	samples []int
+	next    int
+	full    bool
+}
+
+// WithSizeAnalyzer records the fill level after each of the last window
+// writes so RecommendedCapacity can suggest a capacity from the observed
+// p99 occupancy plus headroom.
+func WithSizeAnalyzer(window int) Option {
+	return func(rb *RingBuffer) {
+		if window < 1 {
+			window = 1
+		}
+		rb.sizer = &sizeAnalyzer{samples: make([]int, window)}
+	}
+}
+
+func (sa *sizeAnalyzer) observe(size int) {
+	sa.samples[sa.next] = size
+	sa.next++
+	if sa.next == len(sa.samples) {
+		sa.next = 0
+		sa.full = true
+	}
+}
+
+func (sa *sizeAnalyzer) percentile(p float64) int {
+	n := sa.next
+	if sa.full {
+		n = len(sa.samples)
+	}
+	if n == 0 {
+		return 0
+	}
+	sorted := append([]int(nil), sa.samples[:n]...)
+	sort.Ints(sorted)
+
+	return sorted[int(p*float64(n-1))]
+}
+
+// RecommendedCapacity returns the p99 observed occupancy plus 25%
+// headroom, or 0 without an analyzer or samples.
+func (rb *RingBuffer) RecommendedCapacity() int {
+	if rb.sizer == nil {
+		return 0
+	}
+	p := rb.sizer.percentile(defaultSizePercentile)
+	if p == 0 {
+		return 0
+	}
+
+	return p + int(float64(p)*defaultSizeHeadroom+0.5)
+}
@@ -0,0 +1,71 @@
+package ringbuffer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Stats(t *testing.T) {
+
+	rb := NewRingBuffer(4, WithOverwrite())
+	rb.Write([]byte{1, 2, 3})
+	rb.DropOldest(1)
+	rb.Write([]byte{4, 5, 6})
+
+	assert.Equal(t, Stats{
+		Capacity: 4,
+		Size:     4,
+		Written:  6,
+		Consumed: 2,
+		Lost:     1,
+		NUMANode: -1,
+	}, rb.Stats())
+}
+
+func Test_SizeAnalyzer(t *testing.T) {
+
+	rb := NewRingBuffer(1000, WithSizeAnalyzer(100))
+	assert.Equal(t, 0, rb.RecommendedCapacity())
+
+	out := make([]byte, 1000)
+	for i := 0; i < 200; i++ {
+		n := 100
+		if i%50 == 0 {
+			n = 400
+		}
+		rb.WriteZeros(n)
+		rb.ReadExact(n, out)
+	}
+	// p99 of 100 samples with two 400s is still 400
+	assert.Equal(t, 500, rb.RecommendedCapacity())
+	assert.Equal(t, 500, rb.Stats().RecommendedCapacity)
+
+	for i := 0; i < 100; i++ {
+		rb.WriteZeros(80)
+		rb.ReadExact(80, out)
+	}
+	assert.Equal(t, 100, rb.RecommendedCapacity())
+
+	c := rb.Clone()
+	rb.WriteZeros(800)
+	assert.Equal(t, 100, c.RecommendedCapacity())
+}
+
+func Test_OccupancyHistogram(t *testing.T) {
+
+	rb := NewRingBuffer(8, WithOccupancyHistogram(4))
+	rb.Write([]byte{1})
+	rb.Write([]byte{2, 3, 4})
+	rb.Write([]byte{5, 6, 7, 8})
+	rb.DropOldest(5)
+
+	assert.Equal(t, []uint64{1, 1, 1, 1}, rb.Stats().Occupancy)
+
+	stats := rb.Stats()
+	stats.Occupancy[0] = 100
+	assert.Equal(t, uint64(1), rb.Stats().Occupancy[0])
+
+	plain := NewRingBuffer(8)
+	assert.Nil(t, plain.Stats().Occupancy)
+}
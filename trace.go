@@ -0,0 +1,124 @@
+package ringbuffer
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"time"
+)
+
+type TraceOp int
+
+const (
+	TraceWrite TraceOp = iota
+	TraceRead
+	TraceDrop
+	TraceTruncate
+	TraceUnread
+	TraceReset
+)
+
+func (op TraceOp) String() string {
+	switch op {
+	case TraceWrite:
+		return "write"
+	case TraceRead:
+		return "read"
+	case TraceDrop:
+		return "drop"
+	case TraceTruncate:
+		return "truncate"
+	case TraceUnread:
+		return "unread"
+	case TraceReset:
+		return "reset"
+	}
+	return "unknown"
+}
+
+// TraceEntry describes one completed operation and the positions it left
+// behind.
+type TraceEntry struct {
+	Op        TraceOp
+	Size      int
+	ReadPos   int
+	WritePos  int
+	Buffered  int
+	Goroutine uint64
+	Time      time.Time
+}
+
+type traceRing struct {
+	clock   Clock
+	entries []TraceEntry
+	next    int
+	full    bool
+}
+
+// WithTrace keeps a record of the last n successful operations for
+// post-mortem debugging, retrievable with DumpTrace. Recording looks up
+// the goroutine id, so it is meant for debugging, not production hot
+// paths. A nil clock selects the system clock.
+func WithTrace(n int, clock Clock) Option {
+	return func(rb *RingBuffer) {
+		if n < 1 {
+			n = 1
+		}
+		if clock == nil {
+			clock = systemClock{}
+		}
+		rb.trace = &traceRing{
+			clock:   clock,
+			entries: make([]TraceEntry, n),
+		}
+	}
+}
+
+// DumpTrace returns the recorded operations, oldest first.
+func (rb *RingBuffer) DumpTrace() []TraceEntry {
+	tr := rb.trace
+	if tr == nil {
+		return nil
+	}
+	if !tr.full {
+		return append([]TraceEntry(nil), tr.entries[:tr.next]...)
+	}
+	out := make([]TraceEntry, 0, len(tr.entries))
+	out = append(out, tr.entries[tr.next:]...)
+
+	return append(out, tr.entries[:tr.next]...)
+}
+
+func (rb *RingBuffer) traceOp(op TraceOp, n int) {
+	tr := rb.trace
+	if tr == nil {
+		return
+	}
+	tr.entries[tr.next] = TraceEntry{
+		Op:        op,
+		Size:      n,
+		ReadPos:   rb.readPos,
+		WritePos:  rb.writePos,
+		Buffered:  rb.size,
+		Goroutine: goroutineID(),
+		Time:      tr.clock.Now(),
+	}
+	tr.next++
+	if tr.next == len(tr.entries) {
+		tr.next = 0
+		tr.full = true
+	}
+}
+
+// goroutineID parses the id from the "goroutine N [...]" stack header.
+func goroutineID() uint64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i >= 0 {
+		b = b[:i]
+	}
+	id, _ := strconv.ParseUint(string(b), 10, 64)
+
+	return id
+}
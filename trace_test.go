@@ -0,0 +1,57 @@
+package ringbuffer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/drgolem/ringbuffer/fakeclock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Trace(t *testing.T) {
+
+	clock := fakeclock.New(time.Unix(1000, 0))
+	rb := NewRingBuffer(5, WithTrace(3, clock))
+	assert.Empty(t, rb.DumpTrace())
+
+	rb.Write([]byte{1, 2, 3})
+	clock.Advance(time.Second)
+	rb.ReadExact(2, make([]byte, 2))
+
+	trace := rb.DumpTrace()
+	assert.Equal(t, 2, len(trace))
+	assert.Equal(t, TraceWrite, trace[0].Op)
+	assert.Equal(t, 3, trace[0].Size)
+	assert.Equal(t, 3, trace[0].WritePos)
+	assert.Equal(t, TraceRead, trace[1].Op)
+	assert.Equal(t, 2, trace[1].ReadPos)
+	assert.Equal(t, 1, trace[1].Buffered)
+	assert.Equal(t, time.Unix(1001, 0), trace[1].Time)
+	assert.NotEqual(t, uint64(0), trace[1].Goroutine)
+
+	// failed operations are not recorded
+	rb.ReadExact(4, make([]byte, 4))
+
+	rb.Unread(1)
+	rb.TruncateNewest(1)
+	rb.Reset()
+
+	trace = rb.DumpTrace()
+	assert.Equal(t, 3, len(trace))
+	assert.Equal(t, []string{"unread", "truncate", "reset"},
+		[]string{trace[0].Op.String(), trace[1].Op.String(), trace[2].Op.String()})
+
+	plain := NewRingBuffer(1)
+	assert.Nil(t, plain.DumpTrace())
+}
+
+func Test_GoroutineID(t *testing.T) {
+
+	ids := make(chan uint64)
+	go func() { ids <- goroutineID() }()
+
+	id := goroutineID()
+	other := <-ids
+	assert.NotEqual(t, uint64(0), id)
+	assert.NotEqual(t, id, other)
+}
@@ -0,0 +1,62 @@
+package ringbuffer
+
+// Transformer post-processes bytes in place as they are copied into or
+// out of the buffer, e.g. byte-swapping samples or XOR scrambling. seq is
+// the stream offset of p[0], so position-dependent transforms stay
+// aligned across wraps and partial reads.
+type Transformer interface {
+	Transform(p []byte, seq uint64)
+}
+
+// TransformFunc adapts a plain function to a Transformer.
+type TransformFunc func(p []byte, seq uint64)
+
+func (f TransformFunc) Transform(p []byte, seq uint64) {
+	f(p, seq)
+}
+
+// WithWriteTransform applies ts, in order, to the stored copy of every
+// Write and Fill.
+func WithWriteTransform(ts ...Transformer) Option {
+	return func(rb *RingBuffer) {
+		rb.writeXform = append(rb.writeXform, ts...)
+	}
+}
+
+// WithReadTransform applies ts, in order, to the bytes handed out by
+// copying reads and peeks, and to what HasPrefix and Equal compare
+// against. Only ReadIovec and the %+v preview see the stored bytes
+// unchanged.
+func WithReadTransform(ts ...Transformer) Option {
+	return func(rb *RingBuffer) {
+		rb.readXform = append(rb.readXform, ts...)
+	}
+}
+
+func applyTransforms(ts []Transformer, p []byte, seq uint64) {
+	if len(p) == 0 {
+		return
+	}
+	for _, t := range ts {
+		t.Transform(p, seq)
+	}
+}
+
+// transformWritten runs the write transforms over the segments about to
+// be committed by advanceWrite.
+func (rb *RingBuffer) transformWritten(s1, s2 []byte) {
+	if len(rb.writeXform) == 0 {
+		return
+	}
+	applyTransforms(rb.writeXform, s1, rb.written)
+	applyTransforms(rb.writeXform, s2, rb.written+uint64(len(s1)))
+}
+
+// copyOut copies n buffered bytes starting off bytes past the read
+// position into dst and applies the read transforms.
+func (rb *RingBuffer) copyOut(dst []byte, off, n int) {
+	s1, s2 := rb.readSegments(off, n)
+	copy(dst, s1)
+	copy(dst[len(s1):], s2)
+	applyTransforms(rb.readXform, dst[:n], rb.consumed+uint64(off))
+}
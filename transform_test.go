@@ -0,0 +1,99 @@
+package ringbuffer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func xorKey(key []byte) Transformer {
+	return TransformFunc(func(p []byte, seq uint64) {
+		for i := range p {
+			p[i] ^= key[(seq+uint64(i))%uint64(len(key))]
+		}
+	})
+}
+
+func Test_TransformRoundTrip(t *testing.T) {
+	key := []byte{0x11, 0x22, 0x33, 0x44, 0x55}
+	rb := NewRingBuffer(8, WithWriteTransform(xorKey(key)), WithReadTransform(xorKey(key)))
+
+	var got []byte
+	out := make([]byte, 3)
+	for i := 0; i < 6; i++ {
+		in := []byte{byte(3 * i), byte(3*i + 1), byte(3*i + 2)}
+		_, err := rb.Write(in)
+		assert.NoError(t, err)
+
+		n, err := rb.ReadExact(3, out)
+		assert.NoError(t, err)
+		got = append(got, out[:n]...)
+	}
+	want := make([]byte, 18)
+	for i := range want {
+		want[i] = byte(i)
+	}
+	assert.Equal(t, want, got)
+}
+
+func Test_TransformScramblesStorage(t *testing.T) {
+	key := []byte{0xff}
+	rb := NewRingBuffer(4, WithWriteTransform(xorKey(key)))
+	rb.Write([]byte{1, 2})
+
+	assert.True(t, rb.HasPrefix([]byte{0xfe, 0xfd}))
+	assert.Equal(t, []byte{0xfe, 0xfd}, rb.Bytes())
+}
+
+func Test_TransformChain(t *testing.T) {
+	var order []string
+	first := TransformFunc(func(p []byte, seq uint64) {
+		order = append(order, "first")
+		for i := range p {
+			p[i]++
+		}
+	})
+	second := TransformFunc(func(p []byte, seq uint64) {
+		order = append(order, "second")
+		for i := range p {
+			p[i] *= 2
+		}
+	})
+	rb := NewRingBuffer(4, WithReadTransform(first, second))
+	rb.Write([]byte{1, 2, 3})
+
+	out := make([]byte, 3)
+	rb.ReadExact(3, out)
+	assert.Equal(t, []byte{4, 6, 8}, out)
+	assert.Equal(t, []string{"first", "second"}, order)
+}
+
+func Test_TransformPeekOffset(t *testing.T) {
+	var seqs []uint64
+	rec := TransformFunc(func(p []byte, seq uint64) {
+		seqs = append(seqs, seq)
+	})
+	rb := NewRingBuffer(8, WithReadTransform(rec))
+	rb.Write([]byte("abcdef"))
+	rb.DropOldest(2)
+
+	pc := rb.PeekCursor()
+	pc.Seek(1, 0)
+	pc.Read(make([]byte, 2))
+	rb.ReadLatest(make([]byte, 1))
+
+	assert.Equal(t, []uint64{3, 5}, seqs)
+}
+
+func Test_TransformWriteTo(t *testing.T) {
+	rb := NewRingBuffer(4, WithReadTransform(xorKey([]byte{0x01})))
+	rb.Write([]byte{0, 1, 2})
+
+	var out bytes.Buffer
+	n, err := rb.WriteTo(&out)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+	assert.Equal(t, []byte{1, 0, 3}, out.Bytes())
+	assert.Equal(t, 0, rb.Size())
+}
@@ -0,0 +1,63 @@
+package ringbuffer
+
+import "time"
+
+type ttlTracker struct {
+	clock   Clock
+	ttl     time.Duration
+	stamps  []writeStamp
+	expired uint64
+}
+
+// WithTTL drops data older than ttl instead of delivering it, for live
+// streams where stale audio is worse than lost audio. Eviction happens in
+// Expire and before every Write; consumers call Expire before sizing a
+// read. Each write ages as a whole. A nil clock selects the system clock.
+func WithTTL(ttl time.Duration, clock Clock) Option {
+	return func(rb *RingBuffer) {
+		if clock == nil {
+			clock = systemClock{}
+		}
+		rb.ttl = &ttlTracker{clock: clock, ttl: ttl}
+	}
+}
+
+// Expire drops buffered writes older than the TTL and returns the number
+// of bytes dropped. It is a no-op without WithTTL or while paused.
+func (rb *RingBuffer) Expire() int {
+	if rb.ttl == nil || rb.paused {
+		return 0
+	}
+	t := rb.ttl
+	now := t.clock.Now()
+	cut := rb.consumed
+	i := 0
+	for i < len(t.stamps) && now.Sub(t.stamps[i].at) >= t.ttl {
+		if seqReached(t.stamps[i].end, cut) {
+			cut = t.stamps[i].end
+		}
+		i++
+	}
+	if i > 0 {
+		k := copy(t.stamps, t.stamps[i:])
+		t.stamps = t.stamps[:k]
+	}
+	n := int(cut - rb.consumed)
+	if n == 0 {
+		return 0
+	}
+	t.expired += uint64(n)
+	rb.advanceRead(n)
+	rb.traceOp(TraceDrop, n)
+
+	return n
+}
+
+// ExpiredBytes returns the total number of bytes dropped by the TTL.
+func (rb *RingBuffer) ExpiredBytes() uint64 {
+	if rb.ttl == nil {
+		return 0
+	}
+
+	return rb.ttl.expired
+}
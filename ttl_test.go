@@ -0,0 +1,66 @@
+package ringbuffer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/drgolem/ringbuffer/fakeclock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TTLExpire(t *testing.T) {
+
+	clock := fakeclock.New(time.Unix(1000, 0))
+	rb := NewRingBuffer(16, WithTTL(100*time.Millisecond, clock))
+
+	rb.Write([]byte{1, 2, 3})
+	clock.Advance(60 * time.Millisecond)
+	rb.Write([]byte{4, 5})
+	rb.DropOldest(1)
+
+	clock.Advance(40 * time.Millisecond)
+	assert.Equal(t, 2, rb.Expire())
+	assert.Equal(t, []byte{4, 5}, rb.Bytes())
+
+	clock.Advance(59 * time.Millisecond)
+	assert.Equal(t, 0, rb.Expire())
+
+	// Write evicts before storing.
+	clock.Advance(time.Millisecond)
+	rb.Write([]byte{6})
+	assert.Equal(t, []byte{6}, rb.Bytes())
+	assert.Equal(t, uint64(4), rb.ExpiredBytes())
+	assert.Equal(t, uint64(4), rb.Stats().Expired)
+}
+
+func Test_TTLTruncateAndPause(t *testing.T) {
+
+	clock := fakeclock.New(time.Unix(1000, 0))
+	rb := NewRingBuffer(16, WithTTL(time.Second, clock))
+
+	rb.Write([]byte{1, 2, 3, 4})
+	rb.TruncateNewest(2)
+	clock.Advance(time.Second)
+	rb.Write([]byte{5})
+
+	rb.Pause()
+	clock.Advance(time.Second)
+	assert.Equal(t, 0, rb.Expire())
+	rb.Resume()
+	assert.Equal(t, 1, rb.Expire())
+	assert.Equal(t, 0, rb.Size())
+
+	plain := NewRingBuffer(4)
+	assert.Equal(t, 0, plain.Expire())
+}
+
+func Test_TTLTruncateWholeWrite(t *testing.T) {
+
+	clock := fakeclock.New(time.Unix(1000, 0))
+	rb := NewRingBuffer(16, WithTTL(time.Second, clock))
+
+	rb.Write([]byte{1, 2})
+	rb.Write([]byte{3, 4})
+	rb.TruncateNewest(2)
+	assert.Equal(t, []writeStamp{{end: 2, at: clock.Now()}}, rb.ttl.stamps)
+}
@@ -0,0 +1,97 @@
+package ringbuffer
+
+import (
+	"sync"
+	"time"
+)
+
+type Stall int
+
+const (
+	// StallConsumer means data was pending but nothing was read.
+	StallConsumer Stall = iota
+	// StallProducer means a reader was waiting but nothing was written.
+	StallProducer
+)
+
+func (s Stall) String() string {
+	switch s {
+	case StallConsumer:
+		return "consumer"
+	case StallProducer:
+		return "producer"
+	}
+	return "unknown"
+}
+
+type WatchdogConfig struct {
+	// ConsumerStall is how long data may stay pending without a read.
+	// Zero disables the consumer check.
+	ConsumerStall time.Duration
+	// ProducerStall is how long a reader may wait without a write.
+	// Zero disables the producer check.
+	ProducerStall time.Duration
+	// OnStall is called once per stall episode with how long the side
+	// has been idle. It runs on the watchdog goroutine.
+	OnStall func(s Stall, idle time.Duration)
+}
+
+// StartWatchdog checks for stalled pipelines on a separate goroutine,
+// polling at half the shortest configured threshold. A consumer stall is
+// measured from the later of the last read and the moment data became
+// pending, so the first write after an idle spell does not count the
+// idle time. The returned function stops the watchdog and may be called
+// more than once.
+func (b *BlockingRingBuffer) StartWatchdog(cfg WatchdogConfig) func() {
+	interval := cfg.ConsumerStall
+	if interval == 0 || (cfg.ProducerStall != 0 && cfg.ProducerStall < interval) {
+		interval = cfg.ProducerStall
+	}
+	stop := make(chan struct{})
+	if interval == 0 || cfg.OnStall == nil {
+		return func() {}
+	}
+	interval /= 2
+
+	go func() {
+		var firedRead, firedWrite time.Time
+		for {
+			tick, stopTick := newTimer(b.clock, interval)
+			select {
+			case <-stop:
+				stopTick()
+				return
+			case <-tick:
+			}
+
+			b.mu.Lock()
+			now := b.clock.Now()
+			lastRead, lastWrite := b.lastRead, b.lastWrite
+			if b.pendingSince.After(lastRead) {
+				lastRead = b.pendingSince
+			}
+			pending := b.rb.Size() > 0
+			waiting := b.readWaiters > 0
+			b.mu.Unlock()
+
+			if cfg.ConsumerStall > 0 && pending && lastRead != firedRead {
+				if idle := now.Sub(lastRead); idle >= cfg.ConsumerStall {
+					firedRead = lastRead
+					cfg.OnStall(StallConsumer, idle)
+				}
+			}
+			if cfg.ProducerStall > 0 && waiting && lastWrite != firedWrite {
+				if idle := now.Sub(lastWrite); idle >= cfg.ProducerStall {
+					firedWrite = lastWrite
+					cfg.OnStall(StallProducer, idle)
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() { close(stop) })
+	}
+}
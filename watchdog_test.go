@@ -0,0 +1,130 @@
+package ringbuffer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/drgolem/ringbuffer/fakeclock"
+	"github.com/stretchr/testify/assert"
+)
+
+type stallEvent struct {
+	stall Stall
+	idle  time.Duration
+}
+
+func (b *BlockingRingBuffer) readWaiting() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.readWaiters
+}
+
+func Test_WatchdogConsumerStall(t *testing.T) {
+
+	clock := fakeclock.New(time.Unix(1000, 0))
+	b := NewBlockingRingBuffer(8, clock)
+	events := make(chan stallEvent, 4)
+
+	stop := b.StartWatchdog(WatchdogConfig{
+		ConsumerStall: time.Second,
+		OnStall: func(s Stall, idle time.Duration) {
+			events <- stallEvent{s, idle}
+		},
+	})
+	defer stop()
+
+	b.Write([]byte{1, 2})
+	for i := 0; i < 4; i++ {
+		clock.BlockUntil(1)
+		clock.Advance(500 * time.Millisecond)
+	}
+	clock.BlockUntil(1)
+
+	assert.Equal(t, stallEvent{StallConsumer, time.Second}, <-events)
+	assert.Equal(t, 0, len(events))
+
+	b.Read(make([]byte, 1))
+	for i := 0; i < 2; i++ {
+		clock.BlockUntil(1)
+		clock.Advance(500 * time.Millisecond)
+	}
+	clock.BlockUntil(1)
+	assert.Equal(t, stallEvent{StallConsumer, time.Second}, <-events)
+}
+
+func Test_WatchdogProducerStall(t *testing.T) {
+
+	clock := fakeclock.New(time.Unix(1000, 0))
+	b := NewBlockingRingBuffer(8, clock)
+	events := make(chan stallEvent, 4)
+
+	stop := b.StartWatchdog(WatchdogConfig{
+		ProducerStall: 2 * time.Second,
+		OnStall: func(s Stall, idle time.Duration) {
+			events <- stallEvent{s, idle}
+		},
+	})
+	defer stop()
+
+	done := make(chan struct{})
+	go func() {
+		b.Read(make([]byte, 1))
+		close(done)
+	}()
+	for b.readWaiting() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	for i := 0; i < 2; i++ {
+		clock.BlockUntil(1)
+		clock.Advance(time.Second)
+	}
+	clock.BlockUntil(1)
+	assert.Equal(t, "producer", (<-events).stall.String())
+
+	b.Write([]byte{1})
+	<-done
+}
+
+func Test_WatchdogConsumerStallAfterIdle(t *testing.T) {
+
+	clock := fakeclock.New(time.Unix(1000, 0))
+	b := NewBlockingRingBuffer(8, clock)
+	events := make(chan stallEvent, 4)
+
+	stop := b.StartWatchdog(WatchdogConfig{
+		ConsumerStall: time.Second,
+		OnStall: func(s Stall, idle time.Duration) {
+			events <- stallEvent{s, idle}
+		},
+	})
+	defer stop()
+
+	// empty for a long time: nothing is pending, nothing fires
+	for i := 0; i < 10; i++ {
+		clock.BlockUntil(1)
+		clock.Advance(500 * time.Millisecond)
+	}
+	clock.BlockUntil(1)
+	b.Write([]byte{1})
+
+	clock.Advance(500 * time.Millisecond)
+	clock.BlockUntil(1)
+	assert.Equal(t, 0, len(events))
+
+	clock.Advance(500 * time.Millisecond)
+	clock.BlockUntil(1)
+	assert.Equal(t, stallEvent{StallConsumer, time.Second}, <-events)
+}
+
+func Test_WatchdogStopTwice(t *testing.T) {
+
+	b := NewBlockingRingBuffer(8, fakeclock.New(time.Unix(1000, 0)))
+	stop := b.StartWatchdog(WatchdogConfig{
+		ConsumerStall: time.Second,
+		OnStall:       func(Stall, time.Duration) {},
+	})
+	stop()
+	assert.NotPanics(t, stop)
+}
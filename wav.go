@@ -0,0 +1,70 @@
+package ringbuffer
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// PCMFormat describes interleaved PCM audio held in a buffer.
+type PCMFormat struct {
+	SampleRate    int
+	Channels      int
+	BitsPerSample int
+	// Float marks IEEE float samples, 32 or 64 bits wide; otherwise
+	// samples are signed little-endian integers (unsigned for 8 bits).
+	Float bool
+}
+
+// FrameSize is the size in bytes of one sample for every channel.
+func (f PCMFormat) FrameSize() int {
+	return f.Channels * f.BitsPerSample / 8
+}
+
+func (f PCMFormat) validate() error {
+	if f.SampleRate <= 0 || f.Channels <= 0 || f.BitsPerSample <= 0 || f.BitsPerSample%8 != 0 {
+		return errors.New(fmt.Sprintf("invalid pcm format: %+v", f))
+	}
+	if f.Float && f.BitsPerSample != 32 && f.BitsPerSample != 64 {
+		return errors.New(fmt.Sprintf("invalid pcm format: float samples must be 32 or 64 bits: %+v", f))
+	}
+
+	return nil
+}
+
+// DumpWAV writes the buffered data, without consuming it, to w as a WAV
+// file in format f. A trailing partial frame is left out.
+func (rb *RingBuffer) DumpWAV(w io.Writer, f PCMFormat) error {
+	if err := f.validate(); err != nil {
+		return err
+	}
+	n := rb.size - rb.size%f.FrameSize()
+
+	var tag uint16 = 1
+	if f.Float {
+		tag = 3
+	}
+	var hdr [44]byte
+	copy(hdr[0:], "RIFF")
+	binary.LittleEndian.PutUint32(hdr[4:], uint32(36+n))
+	copy(hdr[8:], "WAVEfmt ")
+	binary.LittleEndian.PutUint32(hdr[16:], 16)
+	binary.LittleEndian.PutUint16(hdr[20:], tag)
+	binary.LittleEndian.PutUint16(hdr[22:], uint16(f.Channels))
+	binary.LittleEndian.PutUint32(hdr[24:], uint32(f.SampleRate))
+	binary.LittleEndian.PutUint32(hdr[28:], uint32(f.SampleRate*f.FrameSize()))
+	binary.LittleEndian.PutUint16(hdr[32:], uint16(f.FrameSize()))
+	binary.LittleEndian.PutUint16(hdr[34:], uint16(f.BitsPerSample))
+	copy(hdr[36:], "data")
+	binary.LittleEndian.PutUint32(hdr[40:], uint32(n))
+	if _, err := w.Write(hdr[:]); err != nil {
+		return err
+	}
+
+	data := make([]byte, n)
+	rb.copyOut(data, 0, n)
+	_, err := w.Write(data)
+
+	return err
+}
@@ -0,0 +1,38 @@
+package ringbuffer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DumpWAV(t *testing.T) {
+
+	rb := NewRingBuffer(16)
+	rb.Write([]byte{0, 0, 0, 0, 1, 2, 3, 4})
+	rb.DropOldest(4)
+	rb.Write([]byte{5, 6, 7, 8, 9, 10, 11, 12, 13})
+
+	var out bytes.Buffer
+	f := PCMFormat{SampleRate: 48000, Channels: 2, BitsPerSample: 16}
+	assert.Nil(t, rb.DumpWAV(&out, f))
+
+	wav := out.Bytes()
+	assert.Equal(t, 44+12, len(wav))
+	assert.Equal(t, "RIFF", string(wav[0:4]))
+	assert.Equal(t, uint32(36+12), binary.LittleEndian.Uint32(wav[4:]))
+	assert.Equal(t, "WAVEfmt ", string(wav[8:16]))
+	assert.Equal(t, uint16(1), binary.LittleEndian.Uint16(wav[20:]))
+	assert.Equal(t, uint16(2), binary.LittleEndian.Uint16(wav[22:]))
+	assert.Equal(t, uint32(48000), binary.LittleEndian.Uint32(wav[24:]))
+	assert.Equal(t, uint32(192000), binary.LittleEndian.Uint32(wav[28:]))
+	assert.Equal(t, uint16(4), binary.LittleEndian.Uint16(wav[32:]))
+	assert.Equal(t, "data", string(wav[36:40]))
+	assert.Equal(t, uint32(12), binary.LittleEndian.Uint32(wav[40:]))
+	assert.Equal(t, []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, wav[44:])
+	assert.Equal(t, 13, rb.Size())
+
+	assert.Error(t, rb.DumpWAV(&out, PCMFormat{SampleRate: 48000, Channels: 2, BitsPerSample: 12}))
+}
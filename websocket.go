@@ -0,0 +1,54 @@
+package ringbuffer
+
+import "io"
+
+// WebSocketConn is the subset of a WebSocket connection the pumps need.
+// *websocket.Conn from gorilla/websocket satisfies it.
+type WebSocketConn interface {
+	ReadMessage() (messageType int, p []byte, err error)
+	WriteMessage(messageType int, data []byte) error
+}
+
+// WebSocketBinary is the binary message type from RFC 6455.
+const WebSocketBinary = 2
+
+// PumpFromWebSocket writes the payload of every binary message read from
+// conn into b, waiting for space as needed so messages larger than the
+// capacity pass through in pieces. Other message types are skipped. It
+// returns the first read or write error.
+func PumpFromWebSocket(conn WebSocketConn, b *BlockingRingBuffer) error {
+	for {
+		typ, p, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		if typ != WebSocketBinary {
+			continue
+		}
+		if _, err := b.Write(p); err != nil {
+			return err
+		}
+	}
+}
+
+// PumpToWebSocket sends what b holds as binary messages of at most
+// maxFrame bytes. It returns nil once b is closed and drained, or the
+// first read or write error.
+func PumpToWebSocket(conn WebSocketConn, b *BlockingRingBuffer, maxFrame int) error {
+	if maxFrame <= 0 {
+		maxFrame = b.Capacity()
+	}
+	buf := make([]byte, maxFrame)
+	for {
+		n, err := b.Read(buf)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := conn.WriteMessage(WebSocketBinary, buf[:n]); err != nil {
+			return err
+		}
+	}
+}
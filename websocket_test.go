@@ -0,0 +1,72 @@
+package ringbuffer
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeWebSocket struct {
+	in  []fakeMessage
+	out [][]byte
+}
+
+type fakeMessage struct {
+	typ  int
+	data []byte
+}
+
+func (ws *fakeWebSocket) ReadMessage() (int, []byte, error) {
+	if len(ws.in) == 0 {
+		return 0, nil, io.EOF
+	}
+	m := ws.in[0]
+	ws.in = ws.in[1:]
+
+	return m.typ, m.data, nil
+}
+
+func (ws *fakeWebSocket) WriteMessage(typ int, data []byte) error {
+	ws.out = append(ws.out, append([]byte(nil), data...))
+
+	return nil
+}
+
+func Test_PumpFromWebSocket(t *testing.T) {
+
+	b := NewBlockingRingBuffer(4, nil)
+	ws := &fakeWebSocket{in: []fakeMessage{
+		{WebSocketBinary, []byte{1, 2}},
+		{1, []byte("text")},
+		{WebSocketBinary, []byte{3, 4, 5, 6, 7, 8}},
+	}}
+
+	var got []byte
+	done := make(chan struct{})
+	go func() {
+		buf := make([]byte, 3)
+		for len(got) < 8 {
+			n, _ := b.Read(buf)
+			got = append(got, buf[:n]...)
+		}
+		close(done)
+	}()
+
+	assert.Equal(t, io.EOF, PumpFromWebSocket(ws, b))
+	<-done
+	assert.Equal(t, []byte{1, 2, 3, 4, 5, 6, 7, 8}, got)
+}
+
+func Test_PumpToWebSocket(t *testing.T) {
+
+	b := NewBlockingRingBuffer(16, nil)
+	b.Write([]byte{1, 2, 3, 4, 5})
+	b.Close()
+
+	ws := &fakeWebSocket{}
+	assert.Nil(t, PumpToWebSocket(ws, b, 2))
+	assert.Equal(t, [][]byte{{1, 2}, {3, 4}, {5}}, ws.out)
+	assert.Equal(t, []byte{1, 2, 3, 4, 5}, bytes.Join(ws.out, nil))
+}
@@ -0,0 +1,20 @@
+//go:build !tinygo
+
+package ringbuffer
+
+import (
+	"io"
+	"net"
+)
+
+func isConn(w io.Writer) bool {
+	_, ok := w.(net.Conn)
+	return ok
+}
+
+// writev passes both segments as net.Buffers so the runtime can issue a
+// single writev.
+func writev(w io.Writer, s1, s2 []byte) (int64, error) {
+	bufs := net.Buffers{s1, s2}
+	return bufs.WriteTo(w)
+}
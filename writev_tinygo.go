@@ -0,0 +1,15 @@
+//go:build tinygo
+
+package ringbuffer
+
+import "io"
+
+// TinyGo targets have no vectored socket writes; WriteTo falls back to
+// one Write per segment.
+func isConn(w io.Writer) bool {
+	return false
+}
+
+func writev(w io.Writer, s1, s2 []byte) (int64, error) {
+	panic("unreachable")
+}